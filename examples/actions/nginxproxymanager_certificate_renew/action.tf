@@ -0,0 +1,5 @@
+action "nginxproxymanager_certificate_renew" "renew" {
+  config {
+    certificate_id = nginxproxymanager_certificate_letsencrypt.certificate.id
+  }
+}
@@ -0,0 +1,5 @@
+action "nginxproxymanager_certificate_test_http" "test" {
+  config {
+    domain_names = ["example.com", "www.example.com"]
+  }
+}
@@ -0,0 +1,7 @@
+action "nginxproxymanager_host_toggle" "maintenance" {
+  config {
+    host_type = "proxy_host"
+    host_id   = nginxproxymanager_proxy_host.example.id
+    enabled   = false
+  }
+}
@@ -1,3 +1,8 @@
 data "nginxproxymanager_certificate" "certificate" {
   id = 1
 }
+
+data "nginxproxymanager_certificate" "app" {
+  domain        = "app.example.com"
+  provider_name = "letsencrypt"
+}
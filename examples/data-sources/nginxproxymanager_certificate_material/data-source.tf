@@ -0,0 +1,3 @@
+data "nginxproxymanager_certificate_material" "certificate" {
+  id = 1
+}
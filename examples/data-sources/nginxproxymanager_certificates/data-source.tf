@@ -1 +1,17 @@
 data "nginxproxymanager_certificates" "certificates" {}
+
+data "nginxproxymanager_certificates" "wildcard" {
+  provider_name = "letsencrypt"
+  domain        = "*.example.com"
+}
+
+check "certificate_expiry" {
+  data "nginxproxymanager_certificates" "expiring" {
+    expires_within = 14
+  }
+
+  assert {
+    condition     = length(data.nginxproxymanager_certificates.expiring.certificates) == 0
+    error_message = "Certificates expire within 14 days: ${join(", ", [for certificate in data.nginxproxymanager_certificates.expiring.certificates : certificate.nice_name])}"
+  }
+}
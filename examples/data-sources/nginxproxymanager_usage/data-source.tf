@@ -0,0 +1,8 @@
+data "nginxproxymanager_usage" "usage" {}
+
+check "missing_references" {
+  assert {
+    condition     = length(data.nginxproxymanager_usage.usage.missing_references) == 0
+    error_message = "Hosts reference missing objects: ${join(", ", [for reference in data.nginxproxymanager_usage.usage.missing_references : "${reference.host_type} ${reference.host_id} (${reference.object_type} ${reference.object_id})"])}"
+  }
+}
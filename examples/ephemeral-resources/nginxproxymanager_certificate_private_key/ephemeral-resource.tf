@@ -0,0 +1,23 @@
+data "nginxproxymanager_certificate_material" "certificate" {
+  id = 1
+}
+
+ephemeral "nginxproxymanager_certificate_private_key" "certificate" {
+  id = data.nginxproxymanager_certificate_material.certificate.id
+}
+
+provider "nginxproxymanager" {
+  alias = "mirror"
+  url   = "https://npm.example.org"
+}
+
+resource "nginxproxymanager_certificate_custom" "mirror" {
+  provider = nginxproxymanager.mirror
+
+  name                     = "example.com"
+  certificate              = data.nginxproxymanager_certificate_material.certificate.certificate
+  intermediate_certificate = data.nginxproxymanager_certificate_material.certificate.chain
+
+  certificate_key_wo         = ephemeral.nginxproxymanager_certificate_private_key.certificate.private_key
+  certificate_key_wo_version = 1
+}
@@ -0,0 +1,8 @@
+ephemeral "nginxproxymanager_user_impersonation_token" "service" {
+  user_id = 2
+}
+
+provider "nginxproxymanager" {
+  alias = "service"
+  token = ephemeral.nginxproxymanager_user_impersonation_token.service.token
+}
@@ -1 +1,6 @@
 ephemeral "nginxproxymanager_user_token" "token" {}
+
+ephemeral "nginxproxymanager_user_token" "short_lived" {
+  expiry = "15m"
+  scope  = "user"
+}
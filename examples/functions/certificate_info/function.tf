@@ -0,0 +1,26 @@
+locals {
+  certificate = provider::nginxproxymanager::certificate_info(file("certificate.pem"), file("certificate.key"))
+}
+
+output "certificate_expires" {
+  value = local.certificate.not_after
+}
+
+resource "nginxproxymanager_certificate_custom" "certificate" {
+  name = "Certificate"
+
+  certificate     = file("certificate.pem")
+  certificate_key = file("certificate.key")
+
+  lifecycle {
+    precondition {
+      condition     = local.certificate.key_matches
+      error_message = "The private key does not belong to the certificate."
+    }
+
+    precondition {
+      condition     = timecmp(local.certificate.not_after, plantimestamp()) > 0
+      error_message = "The certificate expired on ${local.certificate.not_after}."
+    }
+  }
+}
@@ -0,0 +1,9 @@
+# true
+output "matches_subdomain" {
+  value = provider::nginxproxymanager::domain_matches("*.example.com", "app.example.com")
+}
+
+# false, a wildcard matches exactly one label
+output "matches_nested_subdomain" {
+  value = provider::nginxproxymanager::domain_matches("*.example.com", "a.b.example.com")
+}
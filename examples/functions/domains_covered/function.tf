@@ -0,0 +1,24 @@
+locals {
+  domain_names = ["example.com", "www.example.com"]
+}
+
+data "nginxproxymanager_certificate" "certificate" {
+  domain = "example.com"
+}
+
+resource "nginxproxymanager_proxy_host" "proxy_host" {
+  domain_names = local.domain_names
+
+  forward_scheme = "https"
+  forward_host   = "example2.com"
+  forward_port   = 443
+
+  certificate_id = data.nginxproxymanager_certificate.certificate.id
+
+  lifecycle {
+    precondition {
+      condition     = provider::nginxproxymanager::domains_covered(data.nginxproxymanager_certificate.certificate.domain_names, local.domain_names)
+      error_message = "The certificate does not cover all domain names of the proxy host."
+    }
+  }
+}
@@ -0,0 +1,4 @@
+# "xn--bcher-kva.example.com"
+output "domain" {
+  value = provider::nginxproxymanager::normalize_domain("Bücher.Example.com.")
+}
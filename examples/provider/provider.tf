@@ -7,3 +7,9 @@ provider "nginxproxymanager" {
 
 # Environment variable-based authentication
 provider "nginxproxymanager" {}
+
+# Token-based authentication
+provider "nginxproxymanager" {
+  url   = "http://localhost:81"
+  token = var.token
+}
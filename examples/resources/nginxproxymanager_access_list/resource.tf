@@ -25,4 +25,8 @@ resource "nginxproxymanager_access_list" "access_list" {
 
   pass_auth   = false
   satisfy_any = true
+
+  lifecycle {
+    create_before_destroy = true
+  }
 }
@@ -3,4 +3,10 @@ resource "nginxproxymanager_certificate_custom" "certificate" {
 
   certificate     = file("certificate.pem")
   certificate_key = file("certificate.key")
+
+  intermediate_certificate = file("intermediate.pem")
+
+  lifecycle {
+    create_before_destroy = true
+  }
 }
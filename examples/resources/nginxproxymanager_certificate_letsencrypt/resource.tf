@@ -4,7 +4,29 @@ resource "nginxproxymanager_certificate_letsencrypt" "certificate" {
   letsencrypt_email = "admin@example.com"
   letsencrypt_agree = true
 
-  dns_challenge            = true
-  dns_provider             = "cloudflare"
-  dns_provider_credentials = "dns_cloudflare_api_token=0123456789abcdef0123456789abcdef01234567"
+  dns_challenge = true
+  dns_provider  = "cloudflare"
+
+  dns_credentials = {
+    dns_cloudflare_api_token = "0123456789abcdef0123456789abcdef01234567"
+  }
+
+  propagation_seconds = 120
+
+  timeouts {
+    create = "20m"
+  }
+
+  lifecycle {
+    create_before_destroy = true
+  }
+}
+
+resource "nginxproxymanager_certificate_letsencrypt" "renewed" {
+  domain_names = ["example.org"]
+
+  letsencrypt_email = "admin@example.org"
+  letsencrypt_agree = true
+
+  renew_before_days = 14
 }
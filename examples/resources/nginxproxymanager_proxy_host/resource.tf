@@ -32,3 +32,29 @@ resource "nginxproxymanager_proxy_host" "host" {
   hsts_subdomains = false
   http2_support   = true
 }
+
+resource "nginxproxymanager_proxy_host" "app" {
+  domain_names = ["app.example.com"]
+
+  forward_scheme = "http"
+  forward_host   = "app"
+  forward_port   = 8080
+
+  certificate_selection = "best_match"
+  ssl_forced            = true
+}
+
+resource "nginxproxymanager_proxy_host" "shop" {
+  domain_names = ["shop.example.com"]
+
+  forward_scheme = "http"
+  forward_host   = "shop"
+  forward_port   = 8080
+
+  letsencrypt {
+    email = "admin@example.com"
+    agree = true
+  }
+
+  ssl_forced = true
+}
@@ -0,0 +1,2 @@
+# Settings can be imported by specifying any identifier, as there is only one set of settings.
+terraform import nginxproxymanager_settings.settings settings
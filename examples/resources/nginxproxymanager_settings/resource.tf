@@ -2,4 +2,6 @@ resource "nginxproxymanager_settings" "settings" {
   default_site = {
     page = "congratulations"
   }
+
+  reset_on_destroy = true
 }
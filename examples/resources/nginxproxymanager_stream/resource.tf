@@ -6,3 +6,12 @@ resource "nginxproxymanager_stream" "stream" {
   tcp_forwarding = true
   udp_forwarding = false
 }
+
+resource "nginxproxymanager_stream" "tls" {
+  incoming_port   = 8443
+  forwarding_host = "app"
+  forwarding_port = 8080
+
+  certificate_selection    = "best_match_or_none"
+  certificate_domain_names = ["stream.example.com"]
+}
@@ -6,6 +6,7 @@ package provider
 import (
 	"context"
 	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/path"
@@ -25,6 +26,7 @@ import (
 
 var _ resource.Resource = &AccessListResource{}
 var _ resource.ResourceWithImportState = &AccessListResource{}
+var _ resource.ResourceWithValidateConfig = &AccessListResource{}
 
 func NewAccessListResource() resource.Resource {
 	return &AccessListResource{}
@@ -66,10 +68,12 @@ func (r *AccessListResource) Schema(ctx context.Context, req resource.SchemaRequ
 				Required:    true,
 			},
 			"owner_user_id": schema.Int64Attribute{
-				Description: "The ID of the user that owns the access list.",
+				Description: "The ID of the user that owns the access list. When set, the access list is created on behalf of this user. Changing the owner forces a new access list to be created, as Nginx Proxy Manager cannot reassign the owner of an existing access list.",
 				Computed:    true,
+				Optional:    true,
 				PlanModifiers: []planmodifier.Int64{
 					int64planmodifier.UseStateForUnknown(),
+					int64planmodifier.RequiresReplace(),
 				},
 			},
 			"authorizations": schema.SetNestedAttribute{
@@ -84,8 +88,8 @@ func (r *AccessListResource) Schema(ctx context.Context, req resource.SchemaRequ
 							Required:    true,
 						},
 						"password": schema.StringAttribute{
-							Description: "The password hint of the authorization item.",
-							Required:    true,
+							Description: "The password of the authorization item. Required unless the password is set in `authorization_passwords_wo`.",
+							Optional:    true,
 							Sensitive:   true,
 						},
 					},
@@ -124,15 +128,59 @@ func (r *AccessListResource) Schema(ctx context.Context, req resource.SchemaRequ
 				Optional:    true,
 				Default:     booldefault.StaticBool(false),
 			},
+			"authorization_passwords_wo": schema.MapAttribute{
+				Description: "The passwords of the authorization items without a `password`, keyed by username. The passwords are not stored in the state.",
+				ElementType: types.StringType,
+				Optional:    true,
+				Sensitive:   true,
+				WriteOnly:   true,
+			},
+			"authorization_passwords_wo_version": schema.Int64Attribute{
+				Description: "The version of `authorization_passwords_wo`. Change the version to update the passwords.",
+				Optional:    true,
+				Validators: []validator.Int64{
+					int64validator.AlsoRequires(path.MatchRoot("authorization_passwords_wo")),
+				},
+			},
 			"meta": schema.MapAttribute{
 				Description: "The meta data associated with the access list.",
 				ElementType: types.StringType,
 				Computed:    true,
 			},
+			"force_detach": schema.BoolAttribute{
+				MarkdownDescription: "Whether to remove the access list from the hosts that still use it when the access list is deleted. Otherwise deleting the access list fails while hosts use it. When the access list is replaced, set `create_before_destroy` in its `lifecycle` block, so the hosts are moved to the new access list before the old one is deleted. Defaults to `false`.",
+				Computed:            true,
+				Optional:            true,
+				Default:             booldefault.StaticBool(false),
+			},
 		},
 	}
 }
 
+func (r *AccessListResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data *models.AccessListResource
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() || data.Authorizations.IsUnknown() || data.AuthorizationPasswordsWo.IsUnknown() {
+		return
+	}
+
+	authorizations, diags := models.AccessListAuthorizationResourceElementsAs(ctx, data.Authorizations)
+	resp.Diagnostics.Append(diags...)
+
+	passwords := data.AuthorizationPasswordsWo.Elements()
+	for _, authorization := range authorizations {
+		if authorization.Username.IsUnknown() || !authorization.Password.IsNull() {
+			continue
+		}
+
+		if _, ok := passwords[authorization.Username.ValueString()]; !ok {
+			resp.Diagnostics.AddAttributeError(path.Root("authorizations"), "Missing Password", fmt.Sprintf("The authorization item %q has no `password` and no password in `authorization_passwords_wo`.", authorization.Username.ValueString()))
+		}
+	}
+}
+
 func (r *AccessListResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	if data := resourceConfigure(ctx, req, resp); data != nil {
 		r.client = data.Client
@@ -149,14 +197,27 @@ func (r *AccessListResource) Create(ctx context.Context, req resource.CreateRequ
 		return
 	}
 
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("authorization_passwords_wo"), &data.AuthorizationPasswordsWo)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	createAuth, err := ownerAuth(r.client, r.auth, data.OwnerUserId)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("owner_user_id"), "Client Error", fmt.Sprintf("Unable to log in as owner, got error: %s", err))
+		return
+	}
+
 	request := data.ToCreateRequest(ctx, &resp.Diagnostics)
-	accessList, _, err := r.client.AccessListsAPI.CreateAccessList(r.auth).CreateAccessListRequest(*request).Execute()
+	accessList, _, err := r.client.AccessListsAPI.CreateAccessList(createAuth).CreateAccessListRequest(*request).Execute()
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create access list, got error: %s", err))
 		return
 	}
 
 	data.Write(ctx, accessList, &resp.Diagnostics)
+	data.AuthorizationPasswordsWo = types.MapNull(types.StringType)
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
@@ -195,6 +256,12 @@ func (r *AccessListResource) Update(ctx context.Context, req resource.UpdateRequ
 		return
 	}
 
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("authorization_passwords_wo"), &data.AuthorizationPasswordsWo)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	request := data.ToUpdateRequest(ctx, &resp.Diagnostics)
 	accessList, _, err := r.client.AccessListsAPI.UpdateAccessList(r.auth, data.Id.ValueInt64()).UpdateAccessListRequest(*request).Execute()
 	if err != nil {
@@ -203,6 +270,7 @@ func (r *AccessListResource) Update(ctx context.Context, req resource.UpdateRequ
 	}
 
 	data.Write(ctx, accessList, &resp.Diagnostics)
+	data.AuthorizationPasswordsWo = types.MapNull(types.StringType)
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 
@@ -217,6 +285,20 @@ func (r *AccessListResource) Delete(ctx context.Context, req resource.DeleteRequ
 		return
 	}
 
+	refs, err := loadReferences(r.client, r.auth)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to check which hosts use the access list, got error: %s", err))
+		return
+	}
+
+	releaseReferences(refs.AccessLists[data.Id.ValueInt64()], "Access List In Use", "access list", data.ForceDetach.ValueBool(), func(host models.HostReference) error {
+		return detachAccessList(r.client, r.auth, host)
+	}, &resp.Diagnostics)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	success, _, err := r.client.AccessListsAPI.DeleteAccessList(r.auth, data.Id.ValueInt64()).Execute()
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete access list, got error: %s", err))
@@ -4,23 +4,33 @@
 package provider
 
 import (
+	"bytes"
 	"context"
 	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/sander0542/nginxproxymanager-go"
 	"github.com/sander0542/terraform-provider-nginxproxymanager/internal/provider/models"
-	"os"
+	"mime/multipart"
+	"net/http"
 	"strconv"
+	"time"
 )
 
 var _ resource.Resource = &CertificateCustomResource{}
 var _ resource.ResourceWithImportState = &CertificateCustomResource{}
+var _ resource.ResourceWithModifyPlan = &CertificateCustomResource{}
 
 func NewCertificateCustomResource() resource.Resource {
 	return &CertificateCustomResource{}
@@ -58,37 +68,56 @@ func (r *CertificateCustomResource) Schema(ctx context.Context, req resource.Sch
 				Computed:            true,
 			},
 			"owner_user_id": schema.Int64Attribute{
-				MarkdownDescription: "The Id of the user that owns the certificate.",
+				MarkdownDescription: "The Id of the user that owns the certificate. When set, the certificate is created on behalf of this user. Changing the owner forces a new certificate to be created, as Nginx Proxy Manager cannot reassign the owner of an existing certificate.",
 				Computed:            true,
+				Optional:            true,
 				PlanModifiers: []planmodifier.Int64{
 					int64planmodifier.UseStateForUnknown(),
+					int64planmodifier.RequiresReplace(),
 				},
 			},
 			"name": schema.StringAttribute{
-				MarkdownDescription: "The name of the certificate.",
+				MarkdownDescription: "The name of the certificate. The Nginx Proxy Manager API does not support renaming certificates, so changing the name forces a new certificate to be created.",
 				Required:            true,
 				PlanModifiers: []planmodifier.String{
 					stringplanmodifier.RequiresReplace(),
 				},
 			},
 			"certificate": schema.StringAttribute{
-				MarkdownDescription: "The contents of the certificate.",
+				MarkdownDescription: "The contents of the certificate. Changing the certificate uploads it to the existing certificate.",
 				Required:            true,
 				Sensitive:           true,
-				PlanModifiers: []planmodifier.String{
-					stringplanmodifier.RequiresReplace(),
-				},
 			},
 			"certificate_key": schema.StringAttribute{
-				MarkdownDescription: "The contents of the certificate key.",
-				Required:            true,
+				MarkdownDescription: "The contents of the certificate key. Changing the certificate key uploads it to the existing certificate. Exactly one of `certificate_key` or `certificate_key_wo` must be set.",
+				Optional:            true,
 				Sensitive:           true,
-				PlanModifiers: []planmodifier.String{
-					stringplanmodifier.RequiresReplace(),
+				Validators: []validator.String{
+					stringvalidator.ExactlyOneOf(path.MatchRoot("certificate_key_wo")),
+				},
+			},
+			"certificate_key_wo": schema.StringAttribute{
+				MarkdownDescription: "The contents of the certificate key. The certificate key is not stored in the state. Exactly one of `certificate_key` or `certificate_key_wo` must be set.",
+				Optional:            true,
+				Sensitive:           true,
+				WriteOnly:           true,
+				Validators: []validator.String{
+					stringvalidator.AlsoRequires(path.MatchRoot("certificate_key_wo_version")),
+				},
+			},
+			"certificate_key_wo_version": schema.Int64Attribute{
+				MarkdownDescription: "The version of `certificate_key_wo`. Change the version to upload a new certificate key.",
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.AlsoRequires(path.MatchRoot("certificate_key_wo")),
 				},
 			},
+			"intermediate_certificate": schema.StringAttribute{
+				MarkdownDescription: "The contents of the intermediate certificate chain. Changing the intermediate certificate uploads it to the existing certificate.",
+				Optional:            true,
+			},
 			"domain_names": schema.SetAttribute{
-				MarkdownDescription: "The domain names associated with the certificate.",
+				MarkdownDescription: "The domain names associated with the certificate, as stored by Nginx Proxy Manager when the certificate is uploaded.",
 				Computed:            true,
 				ElementType:         types.StringType,
 			},
@@ -96,6 +125,18 @@ func (r *CertificateCustomResource) Schema(ctx context.Context, req resource.Sch
 				MarkdownDescription: "The date and time the certificate expires.",
 				Computed:            true,
 			},
+			"force_detach": schema.BoolAttribute{
+				MarkdownDescription: "Whether to remove the certificate from the hosts that still use it when the certificate is deleted. Otherwise deleting the certificate fails while hosts use it. When the certificate is replaced, set `create_before_destroy` in its `lifecycle` block, so the hosts are moved to the new certificate before the old one is deleted. Defaults to `false`.",
+				Computed:            true,
+				Optional:            true,
+				Default:             booldefault.StaticBool(false),
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Delete: true,
+			}),
 		},
 	}
 }
@@ -116,14 +157,56 @@ func (r *CertificateCustomResource) Create(ctx context.Context, req resource.Cre
 		return
 	}
 
-	err := r.validateCertificate(data)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("certificate_key_wo"), &data.CertificateKeyWo)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.validateCertificate(ctx, data)
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to validate certificate, got error: %s", err))
 		return
 	}
 
+	createAuth, err := ownerAuth(r.client, r.auth, data.OwnerUserId)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("owner_user_id"), "Client Error", fmt.Sprintf("Unable to log in as owner, got error: %s", err))
+		return
+	}
+
+	createTimeout, diags := data.Timeouts.Create(ctx, defaultCertificateCreateTimeout)
+	resp.Diagnostics.Append(diags...)
 	certificateRequest := data.ToCreateRequest(ctx, &resp.Diagnostics)
-	certificate, _, err := r.client.CertificatesAPI.CreateCertificate(r.auth).CreateCertificateRequest(*certificateRequest).Execute()
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	existingIds, err := certificateIds(r.client, createAuth)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read certificates, got error: %s", err))
+		return
+	}
+
+	createCtx, cancel := context.WithTimeout(createAuth, createTimeout)
+	defer cancel()
+
+	certificate, response, err := r.client.CertificatesAPI.CreateCertificate(createCtx).CreateCertificateRequest(*certificateRequest).Execute()
+	if err != nil && isClientTimeout(response, err) {
+		tflog.Warn(ctx, "Request to create certificate timed out, waiting for the certificate to be created", map[string]interface{}{"error": err.Error()})
+
+		pollCtx, pollCancel := pollContext(createAuth, createCtx)
+		defer pollCancel()
+
+		certificate, err = waitForCertificate(pollCtx, r.client, existingIds, func(certificate *nginxproxymanager.GetCertificates200ResponseInner) bool {
+			if !data.OwnerUserId.IsNull() && !data.OwnerUserId.IsUnknown() && certificate.GetOwnerUserId() != data.OwnerUserId.ValueInt64() {
+				return false
+			}
+
+			return certificate.GetProvider() == "other" && certificate.GetNiceName() == certificateRequest.GetNiceName() && sameDomainNames(certificate.GetDomainNames(), certificateRequest.GetDomainNames())
+		})
+	}
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create certificate, got error: %s", err))
 		return
@@ -131,7 +214,10 @@ func (r *CertificateCustomResource) Create(ctx context.Context, req resource.Cre
 
 	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), certificate.GetId())...)
 
-	err = r.uploadCertificate(certificate.GetId(), data)
+	uploadCtx, uploadCancel := context.WithTimeout(ctx, createTimeout)
+	defer uploadCancel()
+
+	err = r.uploadCertificate(uploadCtx, certificate.GetId(), data)
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to upload certificate, got error: %s", err))
 		return
@@ -144,6 +230,7 @@ func (r *CertificateCustomResource) Create(ctx context.Context, req resource.Cre
 	}
 
 	data.Write(ctx, certificate, &resp.Diagnostics)
+	data.CertificateKeyWo = types.StringNull()
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
@@ -174,7 +261,113 @@ func (r *CertificateCustomResource) Read(ctx context.Context, req resource.ReadR
 }
 
 func (r *CertificateCustomResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	resp.Diagnostics.AddError("Client Error", "This resource does not support updates.")
+	var data, state *models.CertificateCustom
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("certificate_key_wo"), &data.CertificateKeyWo)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.validateCertificate(ctx, data)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to validate certificate, got error: %s", err))
+		return
+	}
+
+	// Uploading to the existing certificate keeps the Id, so hosts using the certificate are not affected.
+	err = r.uploadCertificate(ctx, state.Id.ValueInt64(), data)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to upload certificate, got error: %s", err))
+		return
+	}
+
+	certificate, _, err := r.client.CertificatesAPI.GetCertificate(r.auth, state.Id.ValueInt64()).Execute()
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read certificate, got error: %s", err))
+		return
+	}
+
+	data.Write(ctx, certificate, &resp.Diagnostics)
+	data.CertificateKeyWo = types.StringNull()
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *CertificateCustomResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan, state *models.CertificateCustom
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if !req.State.Raw.IsNull() {
+		resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	}
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Nginx Proxy Manager determines the domain names when the certificate is uploaded, so they are kept as stored
+	// while the certificate is unchanged and only known after apply otherwise.
+	if state != nil && state.Certificate.Equal(plan.Certificate) {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("domain_names"), state.DomainNames)...)
+	} else {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("domain_names"), types.SetUnknown(types.StringType))...)
+	}
+
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("certificate_key_wo"), &plan.CertificateKeyWo)...)
+
+	if resp.Diagnostics.HasError() || plan.Certificate.IsUnknown() || plan.GetCertificateKey().IsUnknown() || plan.IntermediateCertificate.IsUnknown() {
+		return
+	}
+
+	certificates, err := models.ParseCertificates(plan.Certificate.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("certificate"), "Invalid Certificate", fmt.Sprintf("Unable to parse certificate: %s", err))
+		return
+	}
+	certificate := certificates[0]
+
+	keyPath := path.Root("certificate_key")
+	if !plan.CertificateKeyWo.IsNull() {
+		keyPath = path.Root("certificate_key_wo")
+	}
+
+	key, err := models.ParsePrivateKey(plan.GetCertificateKey().ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(keyPath, "Invalid Certificate Key", fmt.Sprintf("Unable to parse certificate key: %s", err))
+	} else if !models.PrivateKeyMatches(certificate, key) {
+		resp.Diagnostics.AddAttributeError(keyPath, "Invalid Certificate Key", "The certificate key does not match the certificate.")
+	}
+
+	if !plan.IntermediateCertificate.IsNull() {
+		intermediates, err := models.ParseCertificates(plan.IntermediateCertificate.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("intermediate_certificate"), "Invalid Intermediate Certificate", fmt.Sprintf("Unable to parse intermediate certificate: %s", err))
+			return
+		}
+		certificates = append(certificates, intermediates...)
+	}
+
+	if err := models.VerifyChainOrder(certificates); err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("intermediate_certificate"), "Invalid Certificate Chain", err.Error())
+	}
+
+	if now := time.Now(); certificate.NotAfter.Before(now) {
+		resp.Diagnostics.AddAttributeWarning(path.Root("certificate"), "Certificate Expired", fmt.Sprintf("The certificate expired on %s.", certificate.NotAfter.Format(time.RFC3339)))
+	} else if certificate.NotAfter.Before(now.AddDate(0, 0, 30)) {
+		resp.Diagnostics.AddAttributeWarning(path.Root("certificate"), "Certificate Expires Soon", fmt.Sprintf("The certificate expires on %s.", certificate.NotAfter.Format(time.RFC3339)))
+	}
 }
 
 func (r *CertificateCustomResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
@@ -186,13 +379,42 @@ func (r *CertificateCustomResource) Delete(ctx context.Context, req resource.Del
 		return
 	}
 
-	response, _, err := r.client.CertificatesAPI.DeleteCertificate(r.auth, data.Id.ValueInt64()).Execute()
+	refs, err := loadReferences(r.client, r.auth)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to check which hosts use the certificate, got error: %s", err))
+		return
+	}
+
+	releaseReferences(refs.Certificates[data.Id.ValueInt64()], "Certificate In Use", "certificate", data.ForceDetach.ValueBool(), func(host models.HostReference) error {
+		return detachCertificate(r.client, r.auth, host)
+	}, &resp.Diagnostics)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	deleteTimeout, diags := data.Timeouts.Delete(ctx, defaultCertificateDeleteTimeout)
+	resp.Diagnostics.Append(diags...)
+
+	deleteCtx, cancel := context.WithTimeout(r.auth, deleteTimeout)
+	defer cancel()
+
+	deleted, response, err := r.client.CertificatesAPI.DeleteCertificate(deleteCtx, data.Id.ValueInt64()).Execute()
+	if err != nil && isClientTimeout(response, err) {
+		tflog.Warn(ctx, "Request to delete certificate timed out, waiting for the certificate to be deleted", map[string]interface{}{"error": err.Error()})
+
+		pollCtx, pollCancel := pollContext(r.auth, deleteCtx)
+		defer pollCancel()
+
+		err = waitForCertificateDeleted(pollCtx, r.client, data.Id.ValueInt64())
+		deleted = err == nil
+	}
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete certificate, got error: %s", err))
 		return
 	}
 
-	if !response {
+	if !deleted {
 		resp.Diagnostics.AddError("Server Error", "Unable to delete certificate.")
 		return
 	}
@@ -218,70 +440,46 @@ func (r *CertificateCustomResource) ImportState(ctx context.Context, req resourc
 	resp.Diagnostics.Append(diags...)
 }
 
-func (r *CertificateCustomResource) validateCertificate(data *models.CertificateCustom) error {
-	certFile, err := os.CreateTemp("", "certificate")
-	if err != nil {
-		return err
-	}
-	defer certFile.Close()
-	certKeyFile, err := os.CreateTemp("", "certificate_key")
-	if err != nil {
-		return err
-	}
-	defer certKeyFile.Close()
-
-	_, err = certFile.WriteString(data.Certificate.ValueString())
-	if err != nil {
-		return err
-	}
-	_, err = certFile.Seek(0, 0)
-	if err != nil {
-		return err
-	}
-	_, err = certKeyFile.WriteString(data.CertificateKey.ValueString())
-	if err != nil {
-		return err
-	}
-	_, err = certKeyFile.Seek(0, 0)
-	if err != nil {
-		return err
-	}
-
-	_, _, err = r.client.CertificatesAPI.ValidateCertificates(r.auth).Certificate(certFile).CertificateKey(certKeyFile).Execute()
+func (r *CertificateCustomResource) validateCertificate(ctx context.Context, data *models.CertificateCustom) error {
+	return r.postCertificateFiles(ctx, "/nginx/certificates/validate", data)
+}
 
-	return err
+func (r *CertificateCustomResource) uploadCertificate(ctx context.Context, certId int64, data *models.CertificateCustom) error {
+	return r.postCertificateFiles(ctx, fmt.Sprintf("/nginx/certificates/%d/upload", certId), data)
 }
 
-func (r *CertificateCustomResource) uploadCertificate(certId int64, data *models.CertificateCustom) error {
-	certFile, err := os.CreateTemp("", "certificate")
-	if err != nil {
-		return err
-	}
-	defer certFile.Close()
-	certKeyFile, err := os.CreateTemp("", "certificate_key")
-	if err != nil {
-		return err
+// postCertificateFiles sends the certificate files as a multipart request. The request body is built in memory, so the
+// private key is never written to disk.
+func (r *CertificateCustomResource) postCertificateFiles(ctx context.Context, urlPath string, data *models.CertificateCustom) error {
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+
+	files := []struct {
+		name    string
+		content types.String
+	}{
+		{"certificate", data.Certificate},
+		{"certificate_key", data.GetCertificateKey()},
+		{"intermediate_certificate", data.IntermediateCertificate},
 	}
-	defer certKeyFile.Close()
+	for _, file := range files {
+		if file.content.IsNull() {
+			continue
+		}
 
-	_, err = certFile.WriteString(data.Certificate.ValueString())
-	if err != nil {
-		return err
-	}
-	_, err = certFile.Seek(0, 0)
-	if err != nil {
-		return err
-	}
-	_, err = certKeyFile.WriteString(data.CertificateKey.ValueString())
-	if err != nil {
-		return err
+		part, err := writer.CreateFormFile(file.name, file.name+".pem")
+		if err != nil {
+			return err
+		}
+		if _, err = part.Write([]byte(file.content.ValueString())); err != nil {
+			return err
+		}
 	}
-	_, err = certKeyFile.Seek(0, 0)
-	if err != nil {
+	if err := writer.Close(); err != nil {
 		return err
 	}
 
-	_, _, err = r.client.CertificatesAPI.UploadCertificate(r.auth, certId).Certificate(certFile).CertificateKey(certKeyFile).Execute()
+	_, err := apiRequest(ctx, r.client, r.auth, http.MethodPost, urlPath, body, writer.FormDataContentType())
 
 	return err
 }
@@ -0,0 +1,207 @@
+// Copyright (c) Sander Jochems
+// SPDX-License-Identifier: MIT
+
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/compare"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+)
+
+func TestAccCertificateCustomResourceForceDetach(t *testing.T) {
+	certificate, key := testCertificateInfoPem(t, "force-detach.example.com")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create testing
+			{
+				Config: testAccCertificateCustomResourceCertificateConfig(certificate, key) + testAccCertificateCustomResourceForceDetachHostConfig("nginxproxymanager_certificate_custom.test.id"),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						"nginxproxymanager_proxy_host.test",
+						tfjsonpath.New("certificate_id"),
+						knownvalue.NotNull(),
+					),
+					statecheck.ExpectKnownValue(
+						"nginxproxymanager_proxy_host.test",
+						tfjsonpath.New("hsts_enabled"),
+						knownvalue.Bool(true),
+					),
+				},
+			},
+			// Delete the certificate while the proxy host uses it
+			{
+				Config: testAccCertificateCustomResourceForceDetachHostConfig("null"),
+			},
+			// Read testing
+			{
+				Config: testAccCertificateCustomResourceForceDetachHostConfig("null"),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						"nginxproxymanager_proxy_host.test",
+						tfjsonpath.New("certificate_id"),
+						knownvalue.Null(),
+					),
+					statecheck.ExpectKnownValue(
+						"nginxproxymanager_proxy_host.test",
+						tfjsonpath.New("ssl_forced"),
+						knownvalue.Bool(false),
+					),
+					statecheck.ExpectKnownValue(
+						"nginxproxymanager_proxy_host.test",
+						tfjsonpath.New("hsts_enabled"),
+						knownvalue.Bool(false),
+					),
+					statecheck.ExpectKnownValue(
+						"nginxproxymanager_proxy_host.test",
+						tfjsonpath.New("http2_support"),
+						knownvalue.Bool(false),
+					),
+					statecheck.ExpectKnownValue(
+						"nginxproxymanager_proxy_host.test",
+						tfjsonpath.New("forward_host"),
+						knownvalue.StringExact("example.com"),
+					),
+					statecheck.ExpectKnownValue(
+						"nginxproxymanager_proxy_host.test",
+						tfjsonpath.New("block_exploits"),
+						knownvalue.Bool(true),
+					),
+					statecheck.ExpectKnownValue(
+						"nginxproxymanager_proxy_host.test",
+						tfjsonpath.New("caching_enabled"),
+						knownvalue.Bool(true),
+					),
+					statecheck.ExpectKnownValue(
+						"nginxproxymanager_proxy_host.test",
+						tfjsonpath.New("advanced_config"),
+						knownvalue.StringExact("# force detach"),
+					),
+				},
+			},
+		},
+	})
+}
+
+func TestAccCertificateCustomResourceReplace(t *testing.T) {
+	certificate, key := testCertificateInfoPem(t, "replace.example.com")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create testing
+			{
+				Config: testAccCertificateCustomResourceReplaceConfig("Replace", certificate, key),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.CompareValuePairs(
+						"nginxproxymanager_proxy_host.test",
+						tfjsonpath.New("certificate_id"),
+						"nginxproxymanager_certificate_custom.test",
+						tfjsonpath.New("id"),
+						compare.ValuesSame(),
+					),
+				},
+			},
+			// Replace the certificate while the proxy host uses it
+			{
+				Config: testAccCertificateCustomResourceReplaceConfig("Replaced", certificate, key),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						"nginxproxymanager_certificate_custom.test",
+						tfjsonpath.New("name"),
+						knownvalue.StringExact("Replaced"),
+					),
+					statecheck.CompareValuePairs(
+						"nginxproxymanager_proxy_host.test",
+						tfjsonpath.New("certificate_id"),
+						"nginxproxymanager_certificate_custom.test",
+						tfjsonpath.New("id"),
+						compare.ValuesSame(),
+					),
+				},
+			},
+		},
+	})
+}
+
+// Without create_before_destroy the replaced certificate is deleted while the proxy host still uses it.
+func testAccCertificateCustomResourceReplaceConfig(name, certificate, key string) string {
+	return fmt.Sprintf(`
+resource "nginxproxymanager_certificate_custom" "test" {
+	name = %q
+
+	certificate     = <<EOT
+%s
+EOT
+	certificate_key = <<EOT
+%s
+EOT
+
+	lifecycle {
+		create_before_destroy = true
+	}
+}
+
+resource "nginxproxymanager_proxy_host" "test" {
+	domain_names = ["replace.example.com"]
+
+	forward_scheme = "http"
+	forward_host   = "example.com"
+	forward_port   = 80
+
+	certificate_id = nginxproxymanager_certificate_custom.test.id
+}
+`, name, certificate, key)
+}
+
+func testAccCertificateCustomResourceCertificateConfig(certificate, key string) string {
+	return fmt.Sprintf(`
+resource "nginxproxymanager_certificate_custom" "test" {
+	name = "Force Detach"
+
+	certificate     = <<EOT
+%s
+EOT
+	certificate_key = <<EOT
+%s
+EOT
+
+	force_detach = true
+}
+`, certificate, key)
+}
+
+// The SSL settings are ignored, so the host keeps its configuration while the certificate is detached from it.
+func testAccCertificateCustomResourceForceDetachHostConfig(certificateId string) string {
+	return fmt.Sprintf(`
+resource "nginxproxymanager_proxy_host" "test" {
+	domain_names = ["force-detach.example.com"]
+
+	forward_scheme = "http"
+	forward_host   = "example.com"
+	forward_port   = 80
+
+	certificate_id = %s
+	ssl_forced     = true
+	hsts_enabled   = true
+	http2_support  = true
+
+	block_exploits  = true
+	caching_enabled = true
+	advanced_config = "# force detach"
+
+	lifecycle {
+		ignore_changes = [certificate_id, ssl_forced, hsts_enabled, http2_support]
+	}
+}
+`, certificateId)
+}
@@ -6,6 +6,11 @@ package provider
 import (
 	"context"
 	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework-validators/datasourcevalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/sander0542/terraform-provider-nginxproxymanager/internal/provider/models"
 
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
@@ -15,6 +20,7 @@ import (
 )
 
 var _ datasource.DataSource = &CertificateDataSource{}
+var _ datasource.DataSourceWithConfigValidators = &CertificateDataSource{}
 
 func NewCertificateDataSource() datasource.DataSource {
 	return &CertificateDataSource{}
@@ -34,8 +40,20 @@ func (d *CertificateDataSource) Schema(ctx context.Context, req datasource.Schem
 		MarkdownDescription: "SSL Certificates --- This data source can be used to get information about a specific certificate.",
 		Attributes: map[string]schema.Attribute{
 			"id": schema.Int64Attribute{
-				MarkdownDescription: "The Id of the certificate.",
-				Required:            true,
+				MarkdownDescription: "The Id of the certificate. Conflicts with `domain`, `name` and `provider_name`.",
+				Optional:            true,
+				Computed:            true,
+				Validators: []validator.Int64{
+					int64validator.ConflictsWith(path.MatchRoot("domain"), path.MatchRoot("name"), path.MatchRoot("provider_name")),
+				},
+			},
+			"domain": schema.StringAttribute{
+				MarkdownDescription: "Look up the certificate that covers this domain name. A certificate with the exact domain name is preferred over a certificate with a matching wildcard domain name, followed by the certificate that expires last.",
+				Optional:            true,
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "Look up the certificate with this nice name.",
+				Optional:            true,
 			},
 			"created_on": schema.StringAttribute{
 				MarkdownDescription: "The date and time the certificate was created.",
@@ -50,8 +68,12 @@ func (d *CertificateDataSource) Schema(ctx context.Context, req datasource.Schem
 				Computed:            true,
 			},
 			"provider_name": schema.StringAttribute{
-				MarkdownDescription: "The provider of the certificate.",
+				MarkdownDescription: "The provider of the certificate. When set, the certificate is looked up among the certificates of this provider, either `letsencrypt` or `other`.",
+				Optional:            true,
 				Computed:            true,
+				Validators: []validator.String{
+					stringvalidator.OneOf("letsencrypt", "other"),
+				},
 			},
 			"nice_name": schema.StringAttribute{
 				MarkdownDescription: "The nice name of the certificate.",
@@ -66,6 +88,10 @@ func (d *CertificateDataSource) Schema(ctx context.Context, req datasource.Schem
 				MarkdownDescription: "The date and time the certificate expires.",
 				Computed:            true,
 			},
+			"days_until_expiry": schema.Int64Attribute{
+				MarkdownDescription: "The number of whole days until the certificate expires, which is negative once the certificate has expired.",
+				Computed:            true,
+			},
 			"meta": schema.MapAttribute{
 				MarkdownDescription: "The meta data associated with the certificate.",
 				ElementType:         types.StringType,
@@ -76,6 +102,17 @@ func (d *CertificateDataSource) Schema(ctx context.Context, req datasource.Schem
 	}
 }
 
+func (d *CertificateDataSource) ConfigValidators(ctx context.Context) []datasource.ConfigValidator {
+	return []datasource.ConfigValidator{
+		datasourcevalidator.AtLeastOneOf(
+			path.MatchRoot("id"),
+			path.MatchRoot("domain"),
+			path.MatchRoot("name"),
+			path.MatchRoot("provider_name"),
+		),
+	}
+}
+
 func (d *CertificateDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
 	if data := dataSourceConfigure(ctx, req, resp); data != nil {
 		d.client = data.Client
@@ -84,7 +121,7 @@ func (d *CertificateDataSource) Configure(ctx context.Context, req datasource.Co
 }
 
 func (d *CertificateDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
-	var data *models.Certificate
+	var data *models.CertificateLookup
 
 	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
 
@@ -92,10 +129,34 @@ func (d *CertificateDataSource) Read(ctx context.Context, req datasource.ReadReq
 		return
 	}
 
-	response, _, err := d.client.CertificatesAPI.GetCertificate(d.auth, data.Id.ValueInt64()).Execute()
-	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read certificate, got error: %s", err))
-		return
+	var response *nginxproxymanager.GetCertificates200ResponseInner
+	if !data.Id.IsNull() {
+		var err error
+		response, _, err = d.client.CertificatesAPI.GetCertificate(d.auth, data.Id.ValueInt64()).Execute()
+		if err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read certificate, got error: %s", err))
+			return
+		}
+	} else {
+		certificates, _, err := d.client.CertificatesAPI.GetCertificates(d.auth).Execute()
+		if err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read certificates, got error: %s", err))
+			return
+		}
+
+		criteria := certificateCriteria{
+			Name:     data.Name.ValueString(),
+			Provider: data.Provider.ValueString(),
+		}
+		if !data.Domain.IsNull() {
+			criteria.DomainNames = []string{data.Domain.ValueString()}
+		}
+
+		response, err = bestCertificate(certificates, criteria)
+		if err != nil {
+			resp.Diagnostics.AddError("Certificate Not Found", fmt.Sprintf("Unable to look up certificate, got error: %s", err))
+			return
+		}
 	}
 
 	data.Write(ctx, response, &resp.Diagnostics)
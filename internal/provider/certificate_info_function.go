@@ -0,0 +1,89 @@
+// Copyright (c) Sander Jochems
+// SPDX-License-Identifier: MIT
+
+package provider
+
+import (
+	"context"
+	"crypto"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/sander0542/terraform-provider-nginxproxymanager/internal/provider/models"
+)
+
+var _ function.Function = &CertificateInfoFunction{}
+
+func NewCertificateInfoFunction() function.Function {
+	return &CertificateInfoFunction{}
+}
+
+type CertificateInfoFunction struct{}
+
+func (f *CertificateInfoFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "certificate_info"
+}
+
+func (f *CertificateInfoFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:             "SSL Certificates --- Returns the details of a PEM encoded certificate.",
+		MarkdownDescription: "SSL Certificates --- Parses the first certificate of a PEM encoded certificate and returns its subject, issuer, serial number, subject alternative names, validity period, fingerprints, public key algorithm and whether it is a certificate authority. When a PEM encoded private key is given, `key_matches` contains whether the key belongs to the certificate, otherwise it is `null`.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "certificate",
+				MarkdownDescription: "The PEM encoded certificate.",
+			},
+		},
+		VariadicParameter: function.StringParameter{
+			Name:                "private_key",
+			MarkdownDescription: "An optional PEM encoded private key to compare with the public key of the certificate.",
+		},
+		Return: function.ObjectReturn{
+			AttributeTypes: models.CertificateInfo{}.GetType().(types.ObjectType).AttrTypes,
+		},
+	}
+}
+
+func (f *CertificateInfoFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var content string
+	var privateKeys []string
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, req.Arguments.Get(ctx, &content, &privateKeys))
+
+	if resp.Error != nil {
+		return
+	}
+
+	if len(privateKeys) > 1 {
+		resp.Error = function.NewArgumentFuncError(1, "At most one private key can be given")
+		return
+	}
+
+	certificates, err := models.ParseCertificates(content)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, fmt.Sprintf("Unable to parse the certificate, got error: %s", err))
+		return
+	}
+
+	var key crypto.Signer
+	if len(privateKeys) == 1 {
+		key, err = models.ParsePrivateKey(privateKeys[0])
+		if err != nil {
+			resp.Error = function.NewArgumentFuncError(1, fmt.Sprintf("Unable to parse the private key, got error: %s", err))
+			return
+		}
+	}
+
+	var diags diag.Diagnostics
+	var info models.CertificateInfo
+
+	info.Write(ctx, certificates[0], key, &diags)
+
+	if diags.HasError() {
+		resp.Error = function.FuncErrorFromDiags(ctx, diags)
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, &info))
+}
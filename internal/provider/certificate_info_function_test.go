@@ -0,0 +1,124 @@
+// Copyright (c) Sander Jochems
+// SPDX-License-Identifier: MIT
+
+package provider
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func TestCertificateInfoFunction(t *testing.T) {
+	certificate, key := testCertificateInfoPem(t, "example.com")
+	_, otherKey := testCertificateInfoPem(t, "other.example.com")
+
+	resource.UnitTest(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+locals {
+	certificate = <<EOT
+%s
+EOT
+	key = <<EOT
+%s
+EOT
+	other_key = <<EOT
+%s
+EOT
+}
+
+output "without_key" {
+	value = provider::nginxproxymanager::certificate_info(local.certificate)
+}
+
+output "matching_key" {
+	value = provider::nginxproxymanager::certificate_info(local.certificate, local.key)
+}
+
+output "other_key" {
+	value = provider::nginxproxymanager::certificate_info(local.certificate, local.other_key)
+}
+`, certificate, key, otherKey),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownOutputValueAtPath("without_key", tfjsonpath.New("subject"), knownvalue.StringExact("CN=example.com")),
+					statecheck.ExpectKnownOutputValueAtPath("without_key", tfjsonpath.New("subject_alternative_names"), knownvalue.ListExact([]knownvalue.Check{
+						knownvalue.StringExact("example.com"),
+					})),
+					statecheck.ExpectKnownOutputValueAtPath("without_key", tfjsonpath.New("key_algorithm"), knownvalue.StringExact("ECDSA")),
+					statecheck.ExpectKnownOutputValueAtPath("without_key", tfjsonpath.New("is_ca"), knownvalue.Bool(false)),
+					statecheck.ExpectKnownOutputValueAtPath("without_key", tfjsonpath.New("key_matches"), knownvalue.Null()),
+					statecheck.ExpectKnownOutputValueAtPath("matching_key", tfjsonpath.New("key_matches"), knownvalue.Bool(true)),
+					statecheck.ExpectKnownOutputValueAtPath("other_key", tfjsonpath.New("key_matches"), knownvalue.Bool(false)),
+				},
+			},
+			// Several private keys
+			{
+				Config: fmt.Sprintf(`
+locals {
+	certificate = <<EOT
+%s
+EOT
+	key = <<EOT
+%s
+EOT
+}
+
+output "several_keys" {
+	value = provider::nginxproxymanager::certificate_info(local.certificate, local.key, local.key)
+}
+`, certificate, key),
+				ExpectError: regexp.MustCompile(`At most one private key can be\s+given`),
+			},
+		},
+	})
+}
+
+// testCertificateInfoPem returns a PEM encoded self-signed certificate for the domain name and its private key.
+func testCertificateInfoPem(t *testing.T, domainName string) (string, string) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("unable to generate key: %s", err)
+	}
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: domainName},
+		DNSNames:     []string{domainName},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, key.Public(), key)
+	if err != nil {
+		t.Fatalf("unable to create certificate: %s", err)
+	}
+
+	keyDer, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		t.Fatalf("unable to marshal key: %s", err)
+	}
+
+	return string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})), string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: keyDer}))
+}
@@ -6,26 +6,38 @@ package provider
 import (
 	"context"
 	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework-validators/boolvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/mapvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/setplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/sander0542/nginxproxymanager-go"
 	"github.com/sander0542/terraform-provider-nginxproxymanager/internal/provider/models"
 	"strconv"
 	"sync"
+	"time"
 )
 
 var _ resource.Resource = &CertificateLetsencryptResource{}
 var _ resource.ResourceWithImportState = &CertificateLetsencryptResource{}
+var _ resource.ResourceWithModifyPlan = &CertificateLetsencryptResource{}
+var _ resource.ResourceWithValidateConfig = &CertificateLetsencryptResource{}
+var _ resource.ResourceWithConfigValidators = &CertificateLetsencryptResource{}
 
 func NewCertificateLetsencryptResource() resource.Resource {
 	return &CertificateLetsencryptResource{}
@@ -66,18 +78,30 @@ func (r *CertificateLetsencryptResource) Schema(ctx context.Context, req resourc
 			"expires_on": schema.StringAttribute{
 				MarkdownDescription: "The date and time the certificate expires.",
 				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
 			},
 			"owner_user_id": schema.Int64Attribute{
-				MarkdownDescription: "The Id of the user that owns the certificate.",
+				MarkdownDescription: "The Id of the user that owns the certificate. When set, the certificate is created on behalf of this user. Changing the owner forces a new certificate to be created, as Nginx Proxy Manager cannot reassign the owner of an existing certificate.",
 				Computed:            true,
+				Optional:            true,
 				PlanModifiers: []planmodifier.Int64{
 					int64planmodifier.UseStateForUnknown(),
+					int64planmodifier.RequiresReplace(),
 				},
 			},
 			"domain_names": schema.SetAttribute{
-				MarkdownDescription: "The domain names associated with the certificate.",
+				MarkdownDescription: "The domain names associated with the certificate. A certificate can contain up to 100 domain names.",
 				Required:            true,
 				ElementType:         types.StringType,
+				Validators: []validator.Set{
+					setvalidator.SizeBetween(1, maxCertificateDomainNames),
+					setvalidator.ValueStringsAre(
+						stringvalidator.LengthAtMost(253),
+						stringvalidator.RegexMatches(domainNameRegex, "must be a valid domain name, optionally starting with the wildcard label `*.`"),
+					),
+				},
 				PlanModifiers: []planmodifier.Set{
 					setplanmodifier.RequiresReplace(),
 				},
@@ -100,7 +124,7 @@ func (r *CertificateLetsencryptResource) Schema(ctx context.Context, req resourc
 				},
 			},
 			"dns_challenge": schema.BoolAttribute{
-				MarkdownDescription: "Whether to use DNS validation to request the Let's Encrypt certificate.",
+				MarkdownDescription: "Whether to use DNS validation to request the Let's Encrypt certificate. Required for wildcard domain names.",
 				Computed:            true,
 				Optional:            true,
 				Default:             booldefault.StaticBool(false),
@@ -109,9 +133,12 @@ func (r *CertificateLetsencryptResource) Schema(ctx context.Context, req resourc
 				},
 			},
 			"dns_provider": schema.StringAttribute{
-				MarkdownDescription: "The DNS provider to use for the DNS challenge.",
+				MarkdownDescription: "The DNS provider to use for the DNS challenge. Must be the Id of one of the certbot DNS plugins supported by Nginx Proxy Manager, for example `cloudflare`.",
 				Computed:            true,
 				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(dnsProviderIds()...),
+				},
 				PlanModifiers: []planmodifier.String{
 					stringplanmodifier.RequiresReplace(),
 				},
@@ -125,19 +152,136 @@ func (r *CertificateLetsencryptResource) Schema(ctx context.Context, req resourc
 					stringplanmodifier.RequiresReplace(),
 				},
 			},
+			"dns_credentials": schema.MapAttribute{
+				MarkdownDescription: "The credentials to use for the provider in the DNS challenge, keyed by the credential key, for example `dns_cloudflare_api_token`. The credentials are rendered to the format Nginx Proxy Manager expects.",
+				Optional:            true,
+				Sensitive:           true,
+				ElementType:         types.StringType,
+				Validators: append(dnsCredentialsValidators(),
+					mapvalidator.ConflictsWith(path.MatchRoot("dns_provider_credentials"), path.MatchRoot("dns_provider_credentials_wo")),
+				),
+				PlanModifiers: []planmodifier.Map{
+					mapplanmodifier.RequiresReplace(),
+				},
+			},
+			"dns_provider_credentials_wo": schema.StringAttribute{
+				MarkdownDescription: "The credentials to use for the provider in the DNS challenge. The credentials are not stored in the state.",
+				Optional:            true,
+				Sensitive:           true,
+				WriteOnly:           true,
+				Validators: []validator.String{
+					stringvalidator.ConflictsWith(path.MatchRoot("dns_provider_credentials")),
+					stringvalidator.AlsoRequires(path.MatchRoot("dns_provider_credentials_wo_version")),
+				},
+			},
+			"dns_provider_credentials_wo_version": schema.Int64Attribute{
+				MarkdownDescription: "The version of `dns_provider_credentials_wo`. Changing the version forces a new certificate to be requested with the new credentials.",
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.AlsoRequires(path.MatchRoot("dns_provider_credentials_wo")),
+				},
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+				},
+			},
 			"propagation_seconds": schema.Int64Attribute{
-				MarkdownDescription: "The number of seconds to wait for DNS to propagate before asking the ACME server to verify the DNS record.",
+				MarkdownDescription: "The number of seconds to wait for DNS to propagate before asking the ACME server to verify the DNS record. Can only be set when `dns_challenge` is `true`.",
 				Computed:            true,
 				Optional:            true,
-				Sensitive:           true,
 				PlanModifiers: []planmodifier.Int64{
 					int64planmodifier.RequiresReplace(),
 				},
 			},
+			"preflight_http_check": schema.BoolAttribute{
+				MarkdownDescription: "Whether to test that the domain names are reachable over HTTP before requesting a certificate without DNS challenge. This prevents hitting the Let's Encrypt rate limits for domain names that do not point to Nginx Proxy Manager. Defaults to `false`.",
+				Computed:            true,
+				Optional:            true,
+				Default:             booldefault.StaticBool(false),
+			},
+			"renew_before_days": schema.Int64Attribute{
+				MarkdownDescription: "The number of days before the certificate expires to renew it. When the certificate expires within this window, the certificate is renewed in place, keeping the same Id.",
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"force_detach": schema.BoolAttribute{
+				MarkdownDescription: "Whether to remove the certificate from the hosts that still use it when the certificate is deleted. Otherwise deleting the certificate fails while hosts use it. When the certificate is replaced, set `create_before_destroy` in its `lifecycle` block, so the hosts are moved to the new certificate before the old one is deleted. Defaults to `false`.",
+				Computed:            true,
+				Optional:            true,
+				Default:             booldefault.StaticBool(false),
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Delete: true,
+			}),
 		},
 	}
 }
 
+func (r *CertificateLetsencryptResource) ConfigValidators(ctx context.Context) []resource.ConfigValidator {
+	return []resource.ConfigValidator{
+		wildcardRequiresDnsChallengeValidator{
+			domainNames:  path.Root("domain_names"),
+			dnsChallenge: path.Root("dns_challenge"),
+		},
+		dnsChallengeRequiresProviderValidator{
+			dnsChallenge: path.Root("dns_challenge"),
+			dnsProvider:  path.Root("dns_provider"),
+		},
+		propagationSecondsRequiresDnsChallengeValidator{
+			dnsChallenge:       path.Root("dns_challenge"),
+			propagationSeconds: path.Root("propagation_seconds"),
+		},
+	}
+}
+
+func (r *CertificateLetsencryptResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data *models.CertificateLetsencrypt
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() || data.DnsProvider.IsNull() || data.DnsProvider.IsUnknown() {
+		return
+	}
+
+	dnsProvider, ok := dnsProviders[data.DnsProvider.ValueString()]
+	if !ok {
+		return
+	}
+
+	// Check the credentials that are set for missing keys, the credentials may also be set through a variable that is
+	// not known yet.
+	var credentials map[string]string
+	var attributePath path.Path
+	switch {
+	case !data.DnsCredentials.IsNull():
+		if data.DnsCredentials.IsUnknown() {
+			return
+		}
+		attributePath = path.Root("dns_credentials")
+		resp.Diagnostics.Append(data.DnsCredentials.ElementsAs(ctx, &credentials, false)...)
+	case !data.DnsProviderCredentialsWo.IsNull():
+		if data.DnsProviderCredentialsWo.IsUnknown() {
+			return
+		}
+		attributePath = path.Root("dns_provider_credentials_wo")
+		credentials = models.ParseDnsCredentials(data.DnsProviderCredentialsWo.ValueString())
+	case !data.DnsProviderCredentials.IsNull():
+		if data.DnsProviderCredentials.IsUnknown() {
+			return
+		}
+		attributePath = path.Root("dns_provider_credentials")
+		credentials = models.ParseDnsCredentials(data.DnsProviderCredentials.ValueString())
+	default:
+		return
+	}
+
+	dnsProvider.validateCredentials(attributePath, credentials, &resp.Diagnostics)
+}
+
 func (r *CertificateLetsencryptResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	if data := resourceConfigure(ctx, req, resp); data != nil {
 		r.client = data.Client
@@ -155,17 +299,46 @@ func (r *CertificateLetsencryptResource) Create(ctx context.Context, req resourc
 		return
 	}
 
-	r.mutex.Lock()
-	defer r.mutex.Unlock()
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("dns_provider_credentials_wo"), &data.DnsProviderCredentialsWo)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if data.PreflightHttpCheck.ValueBool() && !data.DnsChallenge.ValueBool() {
+		preflightHttpCheck(ctx, r.client, r.auth, data.DomainNames, &resp.Diagnostics)
 
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	createAuth, err := ownerAuth(r.client, r.auth, data.OwnerUserId)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("owner_user_id"), "Client Error", fmt.Sprintf("Unable to log in as owner, got error: %s", err))
+		return
+	}
+
+	createTimeout, diags := data.Timeouts.Create(ctx, defaultCertificateCreateTimeout)
+	resp.Diagnostics.Append(diags...)
 	certificateRequest := data.ToCreateRequest(ctx, &resp.Diagnostics)
-	certificate, _, err := r.client.CertificatesAPI.CreateCertificate(r.auth).CreateCertificateRequest(*certificateRequest).Execute()
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	certificate, err := createLetsencryptCertificate(ctx, r.client, createAuth, r.mutex, certificateRequest, createTimeout)
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create certificate, got error: %s", err))
 		return
 	}
 
+	if !data.DnsProviderCredentialsWo.IsNull() {
+		data.DnsProviderCredentials = types.StringNull()
+	}
+
 	data.Write(ctx, certificate, &resp.Diagnostics)
+	data.DnsProviderCredentialsWo = types.StringNull()
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
@@ -196,7 +369,57 @@ func (r *CertificateLetsencryptResource) Read(ctx context.Context, req resource.
 }
 
 func (r *CertificateLetsencryptResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	resp.Diagnostics.AddError("Client Error", "This resource does not support updates.")
+	var data, state *models.CertificateLetsencrypt
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// The renewal is only requested when the plan marked the expiry date as unknown.
+	if data.ExpiresOn.IsUnknown() {
+		r.mutex.Lock()
+		defer r.mutex.Unlock()
+
+		certificate, _, err := r.client.CertificatesAPI.RenewCertificate(r.auth, state.Id.ValueInt64()).Execute()
+		if err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to renew certificate, got error: %s", err))
+			return
+		}
+
+		data.Write(ctx, certificate, &resp.Diagnostics)
+	} else {
+		data.ModifiedOn = state.ModifiedOn
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *CertificateLetsencryptResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to renew when the certificate is created or destroyed.
+	if req.State.Raw.IsNull() || req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan, state *models.CertificateLetsencrypt
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	state.RenewBeforeDays = plan.RenewBeforeDays
+	if !state.RenewalDue() {
+		return
+	}
+
+	resp.Diagnostics.AddAttributeWarning(path.Root("expires_on"), "Certificate Renewal", fmt.Sprintf("The certificate expires on %s and will be renewed.", state.ExpiresOn.ValueString()))
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("expires_on"), types.StringUnknown())...)
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("modified_on"), types.StringUnknown())...)
 }
 
 func (r *CertificateLetsencryptResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
@@ -208,13 +431,42 @@ func (r *CertificateLetsencryptResource) Delete(ctx context.Context, req resourc
 		return
 	}
 
-	response, _, err := r.client.CertificatesAPI.DeleteCertificate(r.auth, data.Id.ValueInt64()).Execute()
+	refs, err := loadReferences(r.client, r.auth)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to check which hosts use the certificate, got error: %s", err))
+		return
+	}
+
+	releaseReferences(refs.Certificates[data.Id.ValueInt64()], "Certificate In Use", "certificate", data.ForceDetach.ValueBool(), func(host models.HostReference) error {
+		return detachCertificate(r.client, r.auth, host)
+	}, &resp.Diagnostics)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	deleteTimeout, diags := data.Timeouts.Delete(ctx, defaultCertificateDeleteTimeout)
+	resp.Diagnostics.Append(diags...)
+
+	deleteCtx, cancel := context.WithTimeout(r.auth, deleteTimeout)
+	defer cancel()
+
+	deleted, response, err := r.client.CertificatesAPI.DeleteCertificate(deleteCtx, data.Id.ValueInt64()).Execute()
+	if err != nil && isClientTimeout(response, err) {
+		tflog.Warn(ctx, "Request to delete certificate timed out, waiting for the certificate to be deleted", map[string]interface{}{"error": err.Error()})
+
+		pollCtx, pollCancel := pollContext(r.auth, deleteCtx)
+		defer pollCancel()
+
+		err = waitForCertificateDeleted(pollCtx, r.client, data.Id.ValueInt64())
+		deleted = err == nil
+	}
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete certificate, got error: %s", err))
 		return
 	}
 
-	if !response {
+	if !deleted {
 		resp.Diagnostics.AddError("Server Error", "Unable to delete certificate.")
 		return
 	}
@@ -239,3 +491,59 @@ func (r *CertificateLetsencryptResource) ImportState(ctx context.Context, req re
 	diags := resp.State.SetAttribute(ctx, path.Root("id"), types.Int64Value(certificate.GetId()))
 	resp.Diagnostics.Append(diags...)
 }
+
+// preflightHttpCheck tests whether all domain names are reachable over HTTP, adding an error for every domain name that
+// is not reachable.
+func preflightHttpCheck(ctx context.Context, client *nginxproxymanager.APIClient, auth context.Context, domainNameSet types.Set, diags *diag.Diagnostics) {
+	domainNames, tmpDiags := models.DomainNameElementsAs(ctx, domainNameSet)
+	diags.Append(tmpDiags...)
+
+	if diags.HasError() {
+		return
+	}
+
+	results, err := testHttpReach(client, auth, domainNames)
+	if err != nil {
+		diags.AddError("Client Error", fmt.Sprintf("Unable to test HTTP reachability, got error: %s", err))
+		return
+	}
+
+	for _, domainName := range domainNames {
+		reachable, message := describeHttpReachResult(results[domainName])
+		if reachable {
+			continue
+		}
+
+		diags.AddAttributeError(path.Root("domain_names"), "Domain Not Reachable", fmt.Sprintf("The domain %s is not reachable over HTTP, Nginx Proxy Manager reported %q: %s.", domainName, results[domainName], message))
+	}
+}
+
+// createLetsencryptCertificate requests a Let's Encrypt certificate within the timeout. Certbot keeps running when the
+// request is given up on, so the certificate it issues is adopted instead. The mutex is held while certbot runs, as
+// Nginx Proxy Manager cannot run it concurrently, which is limited by the timeout.
+func createLetsencryptCertificate(ctx context.Context, client *nginxproxymanager.APIClient, auth context.Context, mutex *sync.Mutex, request *nginxproxymanager.CreateCertificateRequest, timeout time.Duration) (*nginxproxymanager.GetCertificates200ResponseInner, error) {
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	existingIds, err := certificateIds(client, auth)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read certificates: %w", err)
+	}
+
+	createCtx, cancel := context.WithTimeout(auth, timeout)
+	defer cancel()
+
+	certificate, response, err := client.CertificatesAPI.CreateCertificate(createCtx).CreateCertificateRequest(*request).Execute()
+	if err != nil && isClientTimeout(response, err) {
+		tflog.Warn(ctx, "Request to create certificate timed out, waiting for the certificate to be issued", map[string]interface{}{"error": err.Error()})
+
+		pollCtx, pollCancel := pollContext(auth, createCtx)
+		defer pollCancel()
+
+		certificate, err = waitForCertificate(pollCtx, client, existingIds, func(certificate *nginxproxymanager.GetCertificates200ResponseInner) bool {
+			return certificate.GetProvider() == "letsencrypt" && sameDomainNames(certificate.GetDomainNames(), request.GetDomainNames()) && isIssued(certificate)
+		})
+	}
+
+	return certificate, err
+}
@@ -0,0 +1,142 @@
+// Copyright (c) Sander Jochems
+// SPDX-License-Identifier: MIT
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"strings"
+)
+
+var _ resource.ConfigValidator = wildcardRequiresDnsChallengeValidator{}
+var _ resource.ConfigValidator = dnsChallengeRequiresProviderValidator{}
+var _ resource.ConfigValidator = propagationSecondsRequiresDnsChallengeValidator{}
+
+// wildcardRequiresDnsChallengeValidator validates that a DNS challenge is used when a wildcard domain is requested,
+// as Let's Encrypt only issues wildcard certificates through the DNS challenge.
+type wildcardRequiresDnsChallengeValidator struct {
+	domainNames  path.Path
+	dnsChallenge path.Path
+}
+
+func (v wildcardRequiresDnsChallengeValidator) Description(_ context.Context) string {
+	return fmt.Sprintf("Wildcard domain names require `%s` to be `true`.", v.dnsChallenge)
+}
+
+func (v wildcardRequiresDnsChallengeValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v wildcardRequiresDnsChallengeValidator) ValidateResource(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var dnsChallenge types.Bool
+	var domainNames types.Set
+
+	if !parentConfigured(ctx, req.Config, v.dnsChallenge, &resp.Diagnostics) {
+		return
+	}
+
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, v.dnsChallenge, &dnsChallenge)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, v.domainNames, &domainNames)...)
+
+	if resp.Diagnostics.HasError() || dnsChallenge.IsUnknown() || dnsChallenge.ValueBool() || domainNames.IsUnknown() {
+		return
+	}
+
+	for _, element := range domainNames.Elements() {
+		domainName, ok := element.(types.String)
+		if !ok || domainName.IsUnknown() || !strings.HasPrefix(domainName.ValueString(), "*.") {
+			continue
+		}
+
+		resp.Diagnostics.AddAttributeError(v.dnsChallenge, "Invalid Attribute Combination", fmt.Sprintf("The wildcard domain name %q can only be requested with a DNS challenge, set `%s` to `true`.", domainName.ValueString(), v.dnsChallenge))
+	}
+}
+
+// dnsChallengeRequiresProviderValidator validates that a DNS provider is set when a DNS challenge is used.
+type dnsChallengeRequiresProviderValidator struct {
+	dnsChallenge path.Path
+	dnsProvider  path.Path
+}
+
+func (v dnsChallengeRequiresProviderValidator) Description(_ context.Context) string {
+	return fmt.Sprintf("`%s` is required when `%s` is `true`.", v.dnsProvider, v.dnsChallenge)
+}
+
+func (v dnsChallengeRequiresProviderValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v dnsChallengeRequiresProviderValidator) ValidateResource(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var dnsChallenge types.Bool
+	var dnsProvider types.String
+
+	if !parentConfigured(ctx, req.Config, v.dnsChallenge, &resp.Diagnostics) {
+		return
+	}
+
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, v.dnsChallenge, &dnsChallenge)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, v.dnsProvider, &dnsProvider)...)
+
+	if resp.Diagnostics.HasError() || !dnsChallenge.ValueBool() {
+		return
+	}
+
+	if dnsProvider.IsNull() {
+		resp.Diagnostics.AddAttributeError(v.dnsProvider, "Missing Attribute", fmt.Sprintf("The attribute `%s` is required when `%s` is `true`.", v.dnsProvider, v.dnsChallenge))
+	}
+}
+
+// propagationSecondsRequiresDnsChallengeValidator validates that the propagation time is only set when a DNS
+// challenge is used.
+type propagationSecondsRequiresDnsChallengeValidator struct {
+	dnsChallenge       path.Path
+	propagationSeconds path.Path
+}
+
+func (v propagationSecondsRequiresDnsChallengeValidator) Description(_ context.Context) string {
+	return fmt.Sprintf("`%s` can only be set when `%s` is `true`.", v.propagationSeconds, v.dnsChallenge)
+}
+
+func (v propagationSecondsRequiresDnsChallengeValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v propagationSecondsRequiresDnsChallengeValidator) ValidateResource(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var dnsChallenge types.Bool
+	var propagationSeconds types.Int64
+
+	if !parentConfigured(ctx, req.Config, v.dnsChallenge, &resp.Diagnostics) {
+		return
+	}
+
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, v.dnsChallenge, &dnsChallenge)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, v.propagationSeconds, &propagationSeconds)...)
+
+	if resp.Diagnostics.HasError() || dnsChallenge.IsUnknown() || dnsChallenge.ValueBool() {
+		return
+	}
+
+	if !propagationSeconds.IsNull() {
+		resp.Diagnostics.AddAttributeError(v.propagationSeconds, "Invalid Attribute Combination", fmt.Sprintf("The attribute `%s` can only be set when `%s` is `true`.", v.propagationSeconds, v.dnsChallenge))
+	}
+}
+
+// parentConfigured returns whether the block that contains the attribute is configured, so the Let's Encrypt settings
+// of a nested block are only validated when the block is used. Attributes at the root are always validated.
+func parentConfigured(ctx context.Context, config tfsdk.Config, attributePath path.Path, diags *diag.Diagnostics) bool {
+	parentPath := attributePath.ParentPath()
+	if len(parentPath.Steps()) == 0 {
+		return true
+	}
+
+	var parent types.Object
+	diags.Append(config.GetAttribute(ctx, parentPath, &parent)...)
+
+	return !diags.HasError() && !parent.IsNull() && !parent.IsUnknown()
+}
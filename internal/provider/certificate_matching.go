@@ -0,0 +1,179 @@
+// Copyright (c) Sander Jochems
+// SPDX-License-Identifier: MIT
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/sander0542/nginxproxymanager-go"
+	"github.com/sander0542/terraform-provider-nginxproxymanager/internal/provider/models"
+	"sort"
+	"strings"
+	"time"
+)
+
+var errNoMatchingCertificate = errors.New("no certificate matches")
+
+// certificateCriteria describes the certificate to look for. Empty criteria are ignored.
+type certificateCriteria struct {
+	DomainNames []string
+	Name        string
+	Provider    string
+}
+
+func (c certificateCriteria) String() string {
+	var parts []string
+	if len(c.DomainNames) > 0 {
+		parts = append(parts, fmt.Sprintf("domain names %s", strings.Join(c.DomainNames, ", ")))
+	}
+	if c.Name != "" {
+		parts = append(parts, fmt.Sprintf("name %q", c.Name))
+	}
+	if c.Provider != "" {
+		parts = append(parts, fmt.Sprintf("provider %q", c.Provider))
+	}
+
+	return strings.Join(parts, " and ")
+}
+
+// bestCertificate returns the certificate that covers all domain names and matches the name and provider. Certificates
+// that cover more domain names with an exact domain name instead of a wildcard are preferred, followed by the
+// certificate that expires last. An error is returned when no certificate matches, or when several certificates match
+// equally well.
+func bestCertificate(certificates []nginxproxymanager.GetCertificates200ResponseInner, criteria certificateCriteria) (*nginxproxymanager.GetCertificates200ResponseInner, error) {
+	best, err := bestCertificates(certificates, criteria)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(best) > 1 {
+		ids := make([]string, 0, len(best))
+		for _, certificate := range best {
+			ids = append(ids, fmt.Sprintf("%d", certificate.GetId()))
+		}
+
+		return nil, fmt.Errorf("several certificates match %s equally well: %s", criteria, strings.Join(ids, ", "))
+	}
+
+	return best[0], nil
+}
+
+// bestCertificates returns the certificates that match the criteria equally well, ranked as described for
+// bestCertificate and ordered by their Id. An error is returned when no certificate matches.
+func bestCertificates(certificates []nginxproxymanager.GetCertificates200ResponseInner, criteria certificateCriteria) ([]*nginxproxymanager.GetCertificates200ResponseInner, error) {
+	var best []*nginxproxymanager.GetCertificates200ResponseInner
+	var bestExact int
+	var bestExpiresOn time.Time
+
+	for i := range certificates {
+		certificate := &certificates[i]
+
+		if criteria.Name != "" && certificate.GetNiceName() != criteria.Name {
+			continue
+		}
+		if criteria.Provider != "" && certificate.GetProvider() != criteria.Provider {
+			continue
+		}
+
+		exact, covered := certificateCoverage(certificate.GetDomainNames(), criteria.DomainNames)
+		if !covered {
+			continue
+		}
+
+		// Certificates without a valid expiry date are ranked last.
+		expiresOn, _ := models.ParseTime(certificate.GetExpiresOn())
+
+		switch {
+		case len(best) == 0 || exact > bestExact || (exact == bestExact && expiresOn.After(bestExpiresOn)):
+			best = []*nginxproxymanager.GetCertificates200ResponseInner{certificate}
+			bestExact = exact
+			bestExpiresOn = expiresOn
+		case exact == bestExact && expiresOn.Equal(bestExpiresOn):
+			best = append(best, certificate)
+		}
+	}
+
+	if len(best) == 0 {
+		return nil, fmt.Errorf("%w %s", errNoMatchingCertificate, criteria)
+	}
+
+	sort.Slice(best, func(i, j int) bool {
+		return best[i].GetId() < best[j].GetId()
+	})
+
+	return best, nil
+}
+
+// certificateCoverage returns whether all domain names are covered by the domain names of the certificate, and how
+// many of them are covered by an exact domain name instead of a wildcard.
+func certificateCoverage(certificateDomainNames []string, domainNames []string) (int, bool) {
+	exact := 0
+	for _, domainName := range domainNames {
+		if !models.DomainCovered(certificateDomainNames, domainName) {
+			return 0, false
+		}
+
+		for _, certificateDomainName := range certificateDomainNames {
+			if !strings.HasPrefix(certificateDomainName, "*.") && models.DomainMatches(certificateDomainName, domainName) {
+				exact++
+				break
+			}
+		}
+	}
+
+	return exact, true
+}
+
+// checkCertificateCoverage adds a warning, or an error when strict is set, when the certificate does not cover all
+// domain names of a host.
+func checkCertificateCoverage(ctx context.Context, client *nginxproxymanager.APIClient, auth context.Context, strict bool, certificateId types.Int64, domainNames types.Set, diags *diag.Diagnostics) {
+	// The certificate or domain names are not known until apply, e.g. when the certificate is created in the same run.
+	// An Id of 0 means the host has no certificate.
+	if certificateId.IsNull() || certificateId.IsUnknown() || certificateId.ValueInt64() == 0 || domainNames.IsUnknown() {
+		return
+	}
+
+	hostDomainNames, tmpDiags := models.DomainNameElementsAs(ctx, domainNames)
+	diags.Append(tmpDiags...)
+
+	if diags.HasError() {
+		return
+	}
+
+	certificate, _, err := client.CertificatesAPI.GetCertificate(auth, certificateId.ValueInt64()).Execute()
+	if err != nil {
+		// The certificate might have been deleted outside of Terraform, which only prevents the check.
+		summary := "Unable to Check Certificate Coverage"
+		detail := fmt.Sprintf("Unable to read certificate %d, got error: %s", certificateId.ValueInt64(), err)
+		if strict {
+			diags.AddAttributeError(path.Root("certificate_id"), summary, detail)
+		} else {
+			diags.AddAttributeWarning(path.Root("certificate_id"), summary, detail)
+		}
+		return
+	}
+
+	var uncovered []string
+	for _, domainName := range hostDomainNames {
+		if !models.DomainCovered(certificate.GetDomainNames(), domainName) {
+			uncovered = append(uncovered, domainName)
+		}
+	}
+
+	if len(uncovered) == 0 {
+		return
+	}
+
+	summary := "Certificate Does Not Cover Domain Names"
+	detail := fmt.Sprintf("The certificate %d (%s) does not cover the domain names %s, so clients will receive TLS errors for these domain names.", certificate.GetId(), certificate.GetNiceName(), strings.Join(uncovered, ", "))
+	if strict {
+		diags.AddAttributeError(path.Root("certificate_id"), summary, detail)
+	} else {
+		diags.AddAttributeWarning(path.Root("certificate_id"), summary, detail+" Set strict_certificate_coverage on the provider to make this an error.")
+	}
+}
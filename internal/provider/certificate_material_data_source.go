@@ -0,0 +1,114 @@
+// Copyright (c) Sander Jochems
+// SPDX-License-Identifier: MIT
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"github.com/sander0542/terraform-provider-nginxproxymanager/internal/provider/models"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/sander0542/nginxproxymanager-go"
+)
+
+var _ datasource.DataSource = &CertificateMaterialDataSource{}
+
+func NewCertificateMaterialDataSource() datasource.DataSource {
+	return &CertificateMaterialDataSource{}
+}
+
+type CertificateMaterialDataSource struct {
+	client *nginxproxymanager.APIClient
+	auth   context.Context
+}
+
+func (d *CertificateMaterialDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_certificate_material"
+}
+
+func (d *CertificateMaterialDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "SSL Certificates --- This data source can be used to download the PEM encoded files of a Let's Encrypt certificate. The private key is only available through the `nginxproxymanager_certificate_private_key` ephemeral resource.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.Int64Attribute{
+				MarkdownDescription: "The Id of the certificate.",
+				Required:            true,
+			},
+			"certificate": schema.StringAttribute{
+				MarkdownDescription: "The PEM encoded certificate.",
+				Computed:            true,
+			},
+			"chain": schema.StringAttribute{
+				MarkdownDescription: "The PEM encoded intermediate certificates.",
+				Computed:            true,
+			},
+			"fullchain": schema.StringAttribute{
+				MarkdownDescription: "The PEM encoded certificate followed by the intermediate certificates.",
+				Computed:            true,
+			},
+			"subject": schema.StringAttribute{
+				MarkdownDescription: "The distinguished name of the subject of the certificate.",
+				Computed:            true,
+			},
+			"issuer": schema.StringAttribute{
+				MarkdownDescription: "The distinguished name of the issuer of the certificate.",
+				Computed:            true,
+			},
+			"serial_number": schema.StringAttribute{
+				MarkdownDescription: "The serial number of the certificate, in hexadecimal.",
+				Computed:            true,
+			},
+			"subject_alternative_names": schema.SetAttribute{
+				MarkdownDescription: "The DNS names and IP addresses in the subject alternative names of the certificate.",
+				Computed:            true,
+				ElementType:         types.StringType,
+			},
+			"not_before": schema.StringAttribute{
+				MarkdownDescription: "The date and time the certificate becomes valid, in RFC 3339 format.",
+				Computed:            true,
+			},
+			"not_after": schema.StringAttribute{
+				MarkdownDescription: "The date and time the certificate expires, in RFC 3339 format.",
+				Computed:            true,
+			},
+			"sha256_fingerprint": schema.StringAttribute{
+				MarkdownDescription: "The SHA-256 fingerprint of the certificate, in hexadecimal.",
+				Computed:            true,
+			},
+			"key_algorithm": schema.StringAttribute{
+				MarkdownDescription: "The algorithm of the public key of the certificate, such as `RSA` or `ECDSA`.",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (d *CertificateMaterialDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if data := dataSourceConfigure(ctx, req, resp); data != nil {
+		d.client = data.Client
+		d.auth = data.Auth
+	}
+}
+
+func (d *CertificateMaterialDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data *models.CertificateMaterial
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	files, err := downloadCertificate(ctx, d.client, d.auth, data.Id.ValueInt64())
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to download certificate, got error: %s", err))
+		return
+	}
+
+	data.Write(ctx, files, &resp.Diagnostics)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,142 @@
+// Copyright (c) Sander Jochems
+// SPDX-License-Identifier: MIT
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"github.com/sander0542/nginxproxymanager-go"
+	"github.com/sander0542/terraform-provider-nginxproxymanager/internal/provider/models"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const (
+	defaultCertificateCreateTimeout = 10 * time.Minute
+	defaultCertificateDeleteTimeout = 2 * time.Minute
+
+	certificatePollInterval    = 5 * time.Second
+	certificatePollGracePeriod = time.Minute
+)
+
+// isClientTimeout returns whether the request was given up on before Nginx Proxy Manager answered, either by the
+// client or by a gateway in front of Nginx Proxy Manager. The operation might still complete on the server.
+func isClientTimeout(response *http.Response, err error) bool {
+	if errors.Is(err, context.DeadlineExceeded) {
+		return true
+	}
+
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return true
+	}
+
+	return response != nil && (response.StatusCode == http.StatusBadGateway || response.StatusCode == http.StatusGatewayTimeout)
+}
+
+// pollContext returns a context for polling which lasts for the remainder of the timeout, but at least for the grace
+// period, so the result can still be checked after the timeout has been reached.
+func pollContext(ctx context.Context, timeoutCtx context.Context) (context.Context, context.CancelFunc) {
+	timeout := certificatePollGracePeriod
+	if deadline, ok := timeoutCtx.Deadline(); ok && time.Until(deadline) > timeout {
+		timeout = time.Until(deadline)
+	}
+
+	return context.WithTimeout(ctx, timeout)
+}
+
+// certificateIds returns the ids of all existing certificates, so a certificate created by a request that timed out
+// can be told apart from the certificates that already existed.
+func certificateIds(client *nginxproxymanager.APIClient, auth context.Context) (map[int64]bool, error) {
+	certificates, _, err := client.CertificatesAPI.GetCertificates(auth).Execute()
+	if err != nil {
+		return nil, err
+	}
+
+	ids := map[int64]bool{}
+	for _, certificate := range certificates {
+		ids[certificate.GetId()] = true
+	}
+
+	return ids, nil
+}
+
+// waitForCertificate polls the certificates until a certificate which did not exist before matches, or the context
+// is done.
+func waitForCertificate(ctx context.Context, client *nginxproxymanager.APIClient, existingIds map[int64]bool, matches func(*nginxproxymanager.GetCertificates200ResponseInner) bool) (*nginxproxymanager.GetCertificates200ResponseInner, error) {
+	for {
+		certificates, _, err := client.CertificatesAPI.GetCertificates(ctx).Execute()
+		if err != nil && ctx.Err() == nil {
+			return nil, err
+		}
+
+		for i := range certificates {
+			if !existingIds[certificates[i].GetId()] && matches(&certificates[i]) {
+				return &certificates[i], nil
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, errors.New("no matching certificate was created before the timeout")
+		case <-time.After(certificatePollInterval):
+		}
+	}
+}
+
+// waitForCertificateDeleted polls the certificate until it no longer exists, or the context is done.
+func waitForCertificateDeleted(ctx context.Context, client *nginxproxymanager.APIClient, id int64) error {
+	for {
+		_, response, err := client.CertificatesAPI.GetCertificate(ctx, id).Execute()
+		if response != nil && response.StatusCode == http.StatusNotFound {
+			return nil
+		}
+		if err != nil && ctx.Err() == nil && !isClientTimeout(response, err) {
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return errors.New("the certificate still exists after the timeout")
+		case <-time.After(certificatePollInterval):
+		}
+	}
+}
+
+// sameDomainNames returns whether both lists contain the same domain names, ignoring order and case.
+func sameDomainNames(a []string, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	names := map[string]bool{}
+	for _, name := range a {
+		names[strings.ToLower(name)] = true
+	}
+	for _, name := range b {
+		if !names[strings.ToLower(name)] {
+			return false
+		}
+	}
+
+	return true
+}
+
+// isIssued returns whether certbot has finished issuing the certificate. Until then, the expiry date of the stored
+// certificate does not lie beyond its creation date.
+func isIssued(certificate *nginxproxymanager.GetCertificates200ResponseInner) bool {
+	createdOn, err := models.ParseTime(certificate.GetCreatedOn())
+	if err != nil {
+		return false
+	}
+
+	expiresOn, err := models.ParseTime(certificate.GetExpiresOn())
+	if err != nil {
+		return false
+	}
+
+	return expiresOn.After(createdOn)
+}
@@ -0,0 +1,80 @@
+// Copyright (c) Sander Jochems
+// SPDX-License-Identifier: MIT
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"github.com/sander0542/nginxproxymanager-go"
+	"github.com/sander0542/terraform-provider-nginxproxymanager/internal/provider/models"
+
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ ephemeral.EphemeralResource = &CertificatePrivateKeyEphemeralResource{}
+var _ ephemeral.EphemeralResourceWithConfigure = &CertificatePrivateKeyEphemeralResource{}
+
+func NewCertificatePrivateKeyEphemeralResource() ephemeral.EphemeralResource {
+	return &CertificatePrivateKeyEphemeralResource{}
+}
+
+type CertificatePrivateKeyEphemeralResource struct {
+	client *nginxproxymanager.APIClient
+	auth   context.Context
+}
+
+func (r *CertificatePrivateKeyEphemeralResource) Metadata(_ context.Context, req ephemeral.MetadataRequest, resp *ephemeral.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_certificate_private_key"
+}
+
+func (r *CertificatePrivateKeyEphemeralResource) Schema(ctx context.Context, _ ephemeral.SchemaRequest, resp *ephemeral.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "SSL Certificates --- This ephemeral resource can be used to retrieve the private key of a Let's Encrypt certificate, without storing it in the state.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.Int64Attribute{
+				MarkdownDescription: "The Id of the certificate.",
+				Required:            true,
+			},
+			"private_key": schema.StringAttribute{
+				Computed:            true,
+				Sensitive:           true,
+				MarkdownDescription: "The PEM encoded private key of the certificate.",
+			},
+		},
+	}
+}
+
+func (r *CertificatePrivateKeyEphemeralResource) Configure(ctx context.Context, req ephemeral.ConfigureRequest, resp *ephemeral.ConfigureResponse) {
+	if data := ephemeralResourceConfigure(ctx, req, resp); data != nil {
+		r.client = data.Client
+		r.auth = data.Auth
+	}
+}
+
+func (r *CertificatePrivateKeyEphemeralResource) Open(ctx context.Context, req ephemeral.OpenRequest, resp *ephemeral.OpenResponse) {
+	var data *models.CertificatePrivateKey
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	files, err := downloadCertificate(ctx, r.client, r.auth, data.Id.ValueInt64())
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to download certificate, got error: %s", err))
+		return
+	}
+
+	if files["privkey"] == "" {
+		resp.Diagnostics.AddError("Client Error", "The downloaded certificate does not contain a private key.")
+		return
+	}
+
+	data.PrivateKey = types.StringValue(files["privkey"])
+
+	resp.Diagnostics.Append(resp.Result.Set(ctx, &data)...)
+}
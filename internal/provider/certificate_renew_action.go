@@ -0,0 +1,78 @@
+// Copyright (c) Sander Jochems
+// SPDX-License-Identifier: MIT
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework/action"
+	"github.com/hashicorp/terraform-plugin-framework/action/schema"
+	"github.com/sander0542/nginxproxymanager-go"
+	"github.com/sander0542/terraform-provider-nginxproxymanager/internal/provider/models"
+	"sync"
+)
+
+var _ action.Action = &CertificateRenewAction{}
+var _ action.ActionWithConfigure = &CertificateRenewAction{}
+
+func NewCertificateRenewAction() action.Action {
+	return &CertificateRenewAction{}
+}
+
+type CertificateRenewAction struct {
+	client *nginxproxymanager.APIClient
+	auth   context.Context
+	mutex  *sync.Mutex
+}
+
+func (a *CertificateRenewAction) Metadata(ctx context.Context, req action.MetadataRequest, resp *action.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_certificate_renew"
+}
+
+func (a *CertificateRenewAction) Schema(ctx context.Context, req action.SchemaRequest, resp *action.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "SSL Certificates --- This action can be used to renew a Let's Encrypt certificate.",
+		Attributes: map[string]schema.Attribute{
+			"certificate_id": schema.Int64Attribute{
+				MarkdownDescription: "The Id of the certificate to renew.",
+				Required:            true,
+			},
+		},
+	}
+}
+
+func (a *CertificateRenewAction) Configure(ctx context.Context, req action.ConfigureRequest, resp *action.ConfigureResponse) {
+	if data := actionConfigure(ctx, req, resp); data != nil {
+		a.client = data.Client
+		a.auth = data.Auth
+		a.mutex = &data.CertificateMutex
+	}
+}
+
+func (a *CertificateRenewAction) Invoke(ctx context.Context, req action.InvokeRequest, resp *action.InvokeResponse) {
+	var data *models.CertificateRenewAction
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	resp.SendProgress(action.InvokeProgressEvent{
+		Message: fmt.Sprintf("Renewing certificate %d", data.CertificateId.ValueInt64()),
+	})
+
+	certificate, _, err := a.client.CertificatesAPI.RenewCertificate(a.auth, data.CertificateId.ValueInt64()).Execute()
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to renew certificate, got error: %s", err))
+		return
+	}
+
+	resp.SendProgress(action.InvokeProgressEvent{
+		Message: fmt.Sprintf("Renewed certificate %d, it now expires on %s", certificate.GetId(), certificate.GetExpiresOn()),
+	})
+}
@@ -0,0 +1,116 @@
+// Copyright (c) Sander Jochems
+// SPDX-License-Identifier: MIT
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/sander0542/nginxproxymanager-go"
+	"github.com/sander0542/terraform-provider-nginxproxymanager/internal/provider/models"
+	"sync"
+)
+
+const (
+	certificateSelectionManual          = "manual"
+	certificateSelectionBestMatch       = "best_match"
+	certificateSelectionBestMatchOrNone = "best_match_or_none"
+)
+
+func certificateSelectionAttribute(domainNamesAttribute string) schema.StringAttribute {
+	return schema.StringAttribute{
+		MarkdownDescription: fmt.Sprintf("How the certificate is selected, either `manual`, `best_match` or `best_match_or_none`. With `manual`, `certificate_id` is used as configured. With `best_match`, the certificate that covers all `%s` is selected during plan, preferring exact domain names over wildcards, then the latest expiry and then the lowest Id, and the plan fails when no certificate matches. With `best_match_or_none`, no certificate is used when no certificate matches. The certificate is selected again on every plan, so a better matching certificate is picked up when it appears. Defaults to `manual`.", domainNamesAttribute),
+		Computed:            true,
+		Optional:            true,
+		Default:             stringdefault.StaticString(certificateSelectionManual),
+		Validators: []validator.String{
+			stringvalidator.OneOf(certificateSelectionManual, certificateSelectionBestMatch, certificateSelectionBestMatchOrNone),
+		},
+	}
+}
+
+// certificateList reads the certificates once per provider configuration, so a plan with many hosts that select their
+// certificate only reads the certificates once.
+type certificateList struct {
+	mutex        sync.Mutex
+	certificates []nginxproxymanager.GetCertificates200ResponseInner
+	loaded       bool
+}
+
+func (l *certificateList) get(client *nginxproxymanager.APIClient, auth context.Context) ([]nginxproxymanager.GetCertificates200ResponseInner, error) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	if !l.loaded {
+		certificates, _, err := client.CertificatesAPI.GetCertificates(auth).Execute()
+		if err != nil {
+			return nil, err
+		}
+
+		l.certificates = certificates
+		l.loaded = true
+	}
+
+	return l.certificates, nil
+}
+
+// validateCertificateSelection adds an error when a certificate is configured while it is selected automatically.
+func validateCertificateSelection(selection types.String, certificateId types.Int64, diags *diag.Diagnostics) {
+	if selection.IsNull() || selection.IsUnknown() || selection.ValueString() == certificateSelectionManual {
+		return
+	}
+
+	if !certificateId.IsNull() {
+		diags.AddAttributeError(path.Root("certificate_id"), "Conflicting Certificate", fmt.Sprintf("The certificate_id cannot be configured when certificate_selection is %q.", selection.ValueString()))
+	}
+}
+
+// resolveCertificateSelection returns the certificate Id to plan for the certificate selection. In manual mode this is
+// the configured certificate Id, otherwise the certificate that best covers all domain names.
+func resolveCertificateSelection(ctx context.Context, certificateList *certificateList, client *nginxproxymanager.APIClient, auth context.Context, selection types.String, configCertificateId types.Int64, domainNames types.Set, diags *diag.Diagnostics) types.Int64 {
+	if selection.IsUnknown() {
+		return types.Int64Unknown()
+	}
+
+	if selection.IsNull() || selection.ValueString() == certificateSelectionManual {
+		return configCertificateId
+	}
+
+	if domainNames.IsNull() || domainNames.IsUnknown() {
+		return types.Int64Unknown()
+	}
+
+	hostDomainNames, tmpDiags := models.DomainNameElementsAs(ctx, domainNames)
+	diags.Append(tmpDiags...)
+
+	if diags.HasError() {
+		return types.Int64Unknown()
+	}
+
+	certificates, err := certificateList.get(client, auth)
+	if err != nil {
+		diags.AddError("Client Error", fmt.Sprintf("Unable to read certificates, got error: %s", err))
+		return types.Int64Unknown()
+	}
+
+	// Certificates that match equally well are told apart by the lowest Id, so the selection does not change between plans.
+	best, err := bestCertificates(certificates, certificateCriteria{DomainNames: hostDomainNames})
+	if err != nil {
+		if errors.Is(err, errNoMatchingCertificate) && selection.ValueString() == certificateSelectionBestMatchOrNone {
+			return types.Int64Null()
+		}
+
+		diags.AddAttributeError(path.Root("certificate_selection"), "Certificate Not Found", fmt.Sprintf("Unable to select certificate, got error: %s", err))
+		return types.Int64Unknown()
+	}
+
+	return types.Int64Value(best[0].GetId())
+}
@@ -0,0 +1,91 @@
+// Copyright (c) Sander Jochems
+// SPDX-License-Identifier: MIT
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/action"
+	"github.com/hashicorp/terraform-plugin-framework/action/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/sander0542/nginxproxymanager-go"
+	"github.com/sander0542/terraform-provider-nginxproxymanager/internal/provider/models"
+)
+
+var _ action.Action = &CertificateTestHttpAction{}
+var _ action.ActionWithConfigure = &CertificateTestHttpAction{}
+
+func NewCertificateTestHttpAction() action.Action {
+	return &CertificateTestHttpAction{}
+}
+
+type CertificateTestHttpAction struct {
+	client *nginxproxymanager.APIClient
+	auth   context.Context
+}
+
+func (a *CertificateTestHttpAction) Metadata(ctx context.Context, req action.MetadataRequest, resp *action.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_certificate_test_http"
+}
+
+func (a *CertificateTestHttpAction) Schema(ctx context.Context, req action.SchemaRequest, resp *action.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "SSL Certificates --- This action can be used to test whether domains are reachable over HTTP by the Let's Encrypt servers.",
+		Attributes: map[string]schema.Attribute{
+			"domain_names": schema.SetAttribute{
+				MarkdownDescription: "The domain names to test.",
+				Required:            true,
+				ElementType:         types.StringType,
+				Validators: []validator.Set{
+					setvalidator.SizeAtLeast(1),
+				},
+			},
+		},
+	}
+}
+
+func (a *CertificateTestHttpAction) Configure(ctx context.Context, req action.ConfigureRequest, resp *action.ConfigureResponse) {
+	if data := actionConfigure(ctx, req, resp); data != nil {
+		a.client = data.Client
+		a.auth = data.Auth
+	}
+}
+
+func (a *CertificateTestHttpAction) Invoke(ctx context.Context, req action.InvokeRequest, resp *action.InvokeResponse) {
+	var data *models.CertificateTestHttpAction
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	domainNames, diags := models.DomainNameElementsAs(ctx, data.DomainNames)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	results, err := testHttpReach(a.client, a.auth, domainNames)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to test HTTP reachability, got error: %s", err))
+		return
+	}
+
+	for _, domainName := range domainNames {
+		reachable, message := describeHttpReachResult(results[domainName])
+
+		resp.SendProgress(action.InvokeProgressEvent{
+			Message: fmt.Sprintf("%s: %s", domainName, message),
+		})
+
+		if !reachable {
+			resp.Diagnostics.AddAttributeError(path.Root("domain_names"), "Domain Not Reachable", fmt.Sprintf("The domain %s is not reachable over HTTP: %s", domainName, message))
+		}
+	}
+}
@@ -6,6 +6,9 @@ package provider
 import (
 	"context"
 	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/sander0542/terraform-provider-nginxproxymanager/internal/provider/models"
 
@@ -33,6 +36,28 @@ func (d *CertificatesDataSource) Schema(ctx context.Context, req datasource.Sche
 	resp.Schema = schema.Schema{
 		MarkdownDescription: "SSL Certificates --- This data source can be used to get information on all certificates.",
 		Attributes: map[string]schema.Attribute{
+			"provider_name": schema.StringAttribute{
+				MarkdownDescription: "Only include certificates of this provider, either `letsencrypt` or `other`.",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.OneOf("letsencrypt", "other"),
+				},
+			},
+			"domain": schema.StringAttribute{
+				MarkdownDescription: "Only include certificates that cover this domain name. A wildcard domain name of a certificate covers exactly one label, so `*.example.com` covers `app.example.com`, but not `example.com`.",
+				Optional:            true,
+			},
+			"expires_within": schema.Int64Attribute{
+				MarkdownDescription: "Only include certificates that expire within this number of days, including certificates that have already expired.",
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+			},
+			"nice_name_regex": schema.StringAttribute{
+				MarkdownDescription: "Only include certificates with a nice name matching this regular expression.",
+				Optional:            true,
+			},
 			"certificates": schema.SetNestedAttribute{
 				MarkdownDescription: "The certificates.",
 				Computed:            true,
@@ -71,6 +96,10 @@ func (d *CertificatesDataSource) Schema(ctx context.Context, req datasource.Sche
 							MarkdownDescription: "The date and time the certificate expires.",
 							Computed:            true,
 						},
+						"days_until_expiry": schema.Int64Attribute{
+							MarkdownDescription: "The number of whole days until the certificate expires, which is negative once the certificate has expired.",
+							Computed:            true,
+						},
 						"meta": schema.MapAttribute{
 							MarkdownDescription: "The meta data associated with the certificate.",
 							ElementType:         types.StringType,
@@ -23,6 +23,8 @@ import (
 
 var _ resource.Resource = &DeadHostResource{}
 var _ resource.ResourceWithImportState = &DeadHostResource{}
+var _ resource.ResourceWithModifyPlan = &DeadHostResource{}
+var _ resource.ResourceWithValidateConfig = &DeadHostResource{}
 
 func NewDeadHostResource() resource.Resource {
 	return &DeadHostResource{}
@@ -31,6 +33,8 @@ func NewDeadHostResource() resource.Resource {
 type DeadHostResource struct {
 	client *nginxproxymanager.APIClient
 	auth   context.Context
+
+	certificates *certificateList
 }
 
 func (r *DeadHostResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -60,10 +64,12 @@ func (r *DeadHostResource) Schema(ctx context.Context, req resource.SchemaReques
 				Computed:            true,
 			},
 			"owner_user_id": schema.Int64Attribute{
-				MarkdownDescription: "The Id of the user that owns the dead host.",
+				MarkdownDescription: "The Id of the user that owns the dead host. When set, the dead host is created on behalf of this user. Changing the owner forces a new dead host to be created, as Nginx Proxy Manager cannot reassign the owner of an existing dead host.",
 				Computed:            true,
+				Optional:            true,
 				PlanModifiers: []planmodifier.Int64{
 					int64planmodifier.UseStateForUnknown(),
+					int64planmodifier.RequiresReplace(),
 				},
 			},
 			"domain_names": schema.SetAttribute{
@@ -72,9 +78,11 @@ func (r *DeadHostResource) Schema(ctx context.Context, req resource.SchemaReques
 				ElementType:         types.StringType,
 			},
 			"certificate_id": schema.Int64Attribute{
-				MarkdownDescription: "The Id of the certificate used by the dead host.",
+				MarkdownDescription: "The Id of the certificate used by the dead host. Can only be configured when `certificate_selection` is `manual`, otherwise the selected certificate is computed.",
+				Computed:            true,
 				Optional:            true,
 			},
+			"certificate_selection": certificateSelectionAttribute("domain_names"),
 			"ssl_forced": schema.BoolAttribute{
 				MarkdownDescription: "Whether SSL is forced for the dead host.",
 				Computed:            true,
@@ -124,11 +132,12 @@ func (r *DeadHostResource) Configure(ctx context.Context, req resource.Configure
 	if data := resourceConfigure(ctx, req, resp); data != nil {
 		r.client = data.Client
 		r.auth = data.Auth
+		r.certificates = &data.Certificates
 	}
 }
 
 func (r *DeadHostResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
-	var data *models.DeadHost
+	var data *models.DeadHostResource
 
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
 
@@ -138,8 +147,14 @@ func (r *DeadHostResource) Create(ctx context.Context, req resource.CreateReques
 
 	hostEnabled := data.Enabled.ValueBool()
 
+	createAuth, err := ownerAuth(r.client, r.auth, data.OwnerUserId)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("owner_user_id"), "Client Error", fmt.Sprintf("Unable to log in as owner, got error: %s", err))
+		return
+	}
+
 	request := data.ToCreateRequest(ctx, &resp.Diagnostics)
-	deadHost, _, err := r.client.Class404HostsAPI.Create404Host(r.auth).Create404HostRequest(*request).Execute()
+	deadHost, _, err := r.client.Class404HostsAPI.Create404Host(createAuth).Create404HostRequest(*request).Execute()
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create dead host, got error: %s", err))
 		return
@@ -149,7 +164,7 @@ func (r *DeadHostResource) Create(ctx context.Context, req resource.CreateReques
 
 	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), data.Id)...)
 
-	err = r.toggleHost(deadHost.GetId(), deadHost.GetEnabled(), hostEnabled)
+	err = toggleDeadHost(r.client, r.auth, deadHost.GetId(), deadHost.GetEnabled(), hostEnabled)
 	if err != nil {
 		resp.Diagnostics.AddAttributeError(path.Root("enabled"), "Client Error", fmt.Sprintf("Unable to update dead host, got err: %s", err))
 		return
@@ -161,7 +176,7 @@ func (r *DeadHostResource) Create(ctx context.Context, req resource.CreateReques
 }
 
 func (r *DeadHostResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
-	var data *models.DeadHost
+	var data *models.DeadHostResource
 
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
 
@@ -186,7 +201,7 @@ func (r *DeadHostResource) Read(ctx context.Context, req resource.ReadRequest, r
 }
 
 func (r *DeadHostResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	var data *models.DeadHost
+	var data *models.DeadHostResource
 
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
 
@@ -205,7 +220,7 @@ func (r *DeadHostResource) Update(ctx context.Context, req resource.UpdateReques
 
 	data.Write(ctx, deadHost, &resp.Diagnostics)
 
-	err = r.toggleHost(deadHost.GetId(), deadHost.GetEnabled(), hostEnabled)
+	err = toggleDeadHost(r.client, r.auth, deadHost.GetId(), deadHost.GetEnabled(), hostEnabled)
 	if err != nil {
 		resp.Diagnostics.AddAttributeError(path.Root("enabled"), "Client Error", fmt.Sprintf("Unable to update dead host, got err: %s", err))
 		return
@@ -217,8 +232,38 @@ func (r *DeadHostResource) Update(ctx context.Context, req resource.UpdateReques
 
 }
 
+func (r *DeadHostResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config *models.DeadHostResource
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	validateCertificateSelection(config.CertificateSelection, config.CertificateId, &resp.Diagnostics)
+}
+
+func (r *DeadHostResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan, config *models.DeadHostResource
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	plan.CertificateId = resolveCertificateSelection(ctx, r.certificates, r.client, r.auth, plan.CertificateSelection, config.CertificateId, plan.DomainNames, &resp.Diagnostics)
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("certificate_id"), plan.CertificateId)...)
+}
+
 func (r *DeadHostResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
-	var data *models.DeadHost
+	var data *models.DeadHostResource
 
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
 
@@ -255,16 +300,16 @@ func (r *DeadHostResource) ImportState(ctx context.Context, req resource.ImportS
 	resp.Diagnostics.Append(diags...)
 }
 
-func (r *DeadHostResource) toggleHost(hostId int64, current bool, desired bool) error {
+func toggleDeadHost(client *nginxproxymanager.APIClient, auth context.Context, hostId int64, current bool, desired bool) error {
 	if desired && !current {
-		enableResponse, _, err := r.client.Class404HostsAPI.EnableDeadHost(r.auth, hostId).Execute()
+		enableResponse, _, err := client.Class404HostsAPI.EnableDeadHost(auth, hostId).Execute()
 		if err != nil {
 			return err
 		} else if !enableResponse {
 			return errors.New("unable to enable dead host")
 		}
 	} else if !desired && current {
-		disableResponse, _, err := r.client.Class404HostsAPI.DisableDeadHost(r.auth, hostId).Execute()
+		disableResponse, _, err := client.Class404HostsAPI.DisableDeadHost(auth, hostId).Execute()
 		if err != nil {
 			return err
 		} else if !disableResponse {
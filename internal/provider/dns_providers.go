@@ -0,0 +1,153 @@
+// Copyright (c) Sander Jochems
+// SPDX-License-Identifier: MIT
+
+package provider
+
+import (
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework-validators/mapvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+var (
+	// dnsCredentialKeyRegex matches a credential key that renders to a single INI key, which is not a comment.
+	dnsCredentialKeyRegex = regexp.MustCompile(`^[^=\s#;][^=\s]*$`)
+	// dnsCredentialValueRegex matches a credential value that renders to a single INI line.
+	dnsCredentialValueRegex = regexp.MustCompile(`^[^\r\n]*$`)
+)
+
+// dnsProvider describes a certbot DNS plugin supported by Nginx Proxy Manager.
+type dnsProvider struct {
+	Name string
+	// RequiredKeys contains the alternative sets of credential keys the plugin accepts. The credentials must contain
+	// all keys of at least one of the sets. A provider without sets does not use INI credentials.
+	RequiredKeys [][]string
+}
+
+// dnsProviders is the catalog of DNS providers supported by Nginx Proxy Manager, keyed by their Id.
+var dnsProviders = map[string]dnsProvider{
+	"acmedns":         {"ACME-DNS", [][]string{{"dns_acmedns_api_url", "dns_acmedns_registration_file"}}},
+	"aliyun":          {"Aliyun", [][]string{{"dns_aliyun_access_key", "dns_aliyun_access_key_secret"}}},
+	"arvan":           {"ArvanCloud", [][]string{{"dns_arvan_key"}}},
+	"azure":           {"Azure", [][]string{{"dns_azure_sp_client_id", "dns_azure_sp_client_secret", "dns_azure_tenant_id"}, {"dns_azure_msi_client_id"}, {"dns_azure_msi_system_assigned"}}},
+	"beget":           {"Beget", [][]string{{"beget_plugin_username", "beget_plugin_password"}}},
+	"bunny":           {"bunny.net", [][]string{{"dns_bunny_api_key"}}},
+	"cloudflare":      {"Cloudflare", [][]string{{"dns_cloudflare_api_token"}, {"dns_cloudflare_email", "dns_cloudflare_api_key"}}},
+	"cloudns":         {"ClouDNS", [][]string{{"dns_cloudns_auth_password", "dns_cloudns_auth_id"}, {"dns_cloudns_auth_password", "dns_cloudns_sub_auth_id"}}},
+	"cloudxns":        {"CloudXNS", [][]string{{"dns_cloudxns_api_key", "dns_cloudxns_secret_key"}}},
+	"constellix":      {"Constellix", [][]string{{"dns_constellix_apikey", "dns_constellix_secretkey", "dns_constellix_endpoint"}}},
+	"corenetworks":    {"Core Networks", [][]string{{"dns_corenetworks_username", "dns_corenetworks_password"}}},
+	"cpanel":          {"cPanel", [][]string{{"cpanel_url", "cpanel_username", "cpanel_password"}, {"cpanel_url", "cpanel_username", "cpanel_token"}}},
+	"desec":           {"deSEC", [][]string{{"dns_desec_token"}}},
+	"digitalocean":    {"DigitalOcean", [][]string{{"dns_digitalocean_token"}}},
+	"directadmin":     {"DirectAdmin", [][]string{{"directadmin_url", "directadmin_username", "directadmin_password"}}},
+	"dnsimple":        {"DNSimple", [][]string{{"dns_dnsimple_token"}}},
+	"dnsmadeeasy":     {"DNS Made Easy", [][]string{{"dns_dnsmadeeasy_api_key", "dns_dnsmadeeasy_secret_key"}}},
+	"dnsmulti":        {"DnsMulti", [][]string{{"dns_multi_provider"}}},
+	"dnspod":          {"DNSPod", [][]string{{"dns_dnspod_email", "dns_dnspod_api_token"}}},
+	"domainoffensive": {"DomainOffensive (do.de)", [][]string{{"dns_do_api_token"}}},
+	"domeneshop":      {"Domeneshop", [][]string{{"dns_domeneshop_client_token", "dns_domeneshop_client_secret"}}},
+	"dreamhost":       {"DreamHost", [][]string{{"dreamhost_baseurl", "dreamhost_api_key"}}},
+	"duckdns":         {"DuckDNS", [][]string{{"dns_duckdns_token"}}},
+	"dynu":            {"Dynu", [][]string{{"dns_dynu_auth_token"}}},
+	"easydns":         {"easyDNS", [][]string{{"dns_easydns_usertoken", "dns_easydns_userkey", "dns_easydns_endpoint"}}},
+	"edgedns":         {"Akamai Edge DNS", [][]string{{"edgedns_client_token", "edgedns_client_secret", "edgedns_access_token", "edgedns_host"}, {"edgedns_edgerc_path", "edgedns_edgerc_section"}}},
+	"eurodns":         {"EuroDNS", [][]string{{"dns_eurodns_applicationId", "dns_eurodns_apiKey"}}},
+	"freedns":         {"FreeDNS", [][]string{{"dns_freedns_username", "dns_freedns_password"}}},
+	"gandi":           {"Gandi Live DNS", [][]string{{"dns_gandi_token"}, {"dns_gandi_api_key"}}},
+	"gcore":           {"Gcore DNS", [][]string{{"dns_gcore_apitoken"}}},
+	"glesys":          {"Glesys", [][]string{{"dns_glesys_user", "dns_glesys_password"}}},
+	"godaddy":         {"GoDaddy", [][]string{{"dns_godaddy_secret", "dns_godaddy_key"}}},
+	"google":          {"Google", nil},
+	"he":              {"Hurricane Electric", [][]string{{"dns_he_user", "dns_he_pass"}}},
+	"hetzner":         {"Hetzner", [][]string{{"dns_hetzner_api_token"}}},
+	"infomaniak":      {"Infomaniak", [][]string{{"dns_infomaniak_token"}}},
+	"inwx":            {"INWX", [][]string{{"dns_inwx_url", "dns_inwx_username", "dns_inwx_password"}}},
+	"ionos":           {"IONOS", [][]string{{"dns_ionos_prefix", "dns_ionos_secret", "dns_ionos_endpoint"}}},
+	"ispconfig":       {"ISPConfig", [][]string{{"dns_ispconfig_username", "dns_ispconfig_password", "dns_ispconfig_endpoint"}}},
+	"isset":           {"Isset", [][]string{{"dns_isset_endpoint", "dns_isset_token"}}},
+	"joker":           {"Joker", [][]string{{"dns_joker_username", "dns_joker_password", "dns_joker_domain"}}},
+	"kas":             {"All-Inkl", [][]string{{"dns_kas_user", "dns_kas_password"}}},
+	"leaseweb":        {"LeaseWeb", [][]string{{"dns_leaseweb_api_token"}}},
+	"linode":          {"Linode", [][]string{{"dns_linode_key"}}},
+	"loopia":          {"Loopia", [][]string{{"dns_loopia_user", "dns_loopia_password"}}},
+	"luadns":          {"LuaDNS", [][]string{{"dns_luadns_email", "dns_luadns_token"}}},
+	"mijnhost":        {"mijn.host", [][]string{{"dns_mijn_host_api_key"}}},
+	"namecheap":       {"Namecheap", [][]string{{"dns_namecheap_username", "dns_namecheap_api_key"}}},
+	"netcup":          {"netcup", [][]string{{"dns_netcup_customer_id", "dns_netcup_api_key", "dns_netcup_api_password"}}},
+	"njalla":          {"Njalla", [][]string{{"dns_njalla_token"}}},
+	"nsone":           {"NS1", [][]string{{"dns_nsone_api_key"}}},
+	"online":          {"Online", [][]string{{"dns_online_token"}}},
+	"ovh":             {"OVH", [][]string{{"dns_ovh_endpoint", "dns_ovh_application_key", "dns_ovh_application_secret", "dns_ovh_consumer_key"}}},
+	"plesk":           {"Plesk", [][]string{{"dns_plesk_username", "dns_plesk_password", "dns_plesk_api_url"}}},
+	"porkbun":         {"Porkbun", [][]string{{"dns_porkbun_key", "dns_porkbun_secret"}}},
+	"powerdns":        {"PowerDNS", [][]string{{"dns_powerdns_api_url", "dns_powerdns_api_key"}}},
+	"regru":           {"reg.ru", [][]string{{"dns_regru_username", "dns_regru_password"}}},
+	"rfc2136":         {"RFC 2136", [][]string{{"dns_rfc2136_server", "dns_rfc2136_name", "dns_rfc2136_secret", "dns_rfc2136_algorithm"}}},
+	"route53":         {"Route 53 (Amazon)", [][]string{{"aws_access_key_id", "aws_secret_access_key"}}},
+	"simply":          {"Simply", [][]string{{"dns_simply_account_name", "dns_simply_api_key"}}},
+	"strato":          {"Strato", [][]string{{"dns_strato_username", "dns_strato_password"}}},
+	"tencentcloud":    {"Tencent Cloud", [][]string{{"dns_tencentcloud_secret_id", "dns_tencentcloud_secret_key"}}},
+	"transip":         {"TransIP", [][]string{{"dns_transip_username", "dns_transip_key_file"}}},
+	"vultr":           {"Vultr", [][]string{{"dns_vultr_key"}}},
+	"websupport":      {"Websupport.sk", [][]string{{"dns_websupport_identifier", "dns_websupport_secret_key"}}},
+	"wedos":           {"Wedos", [][]string{{"dns_wedos_user", "dns_wedos_auth"}}},
+	"zoneedit":        {"ZoneEdit", [][]string{{"dns_zoneedit_user", "dns_zoneedit_token"}}},
+}
+
+// dnsProviderIds returns the sorted Ids of all DNS providers in the catalog.
+func dnsProviderIds() []string {
+	ids := make([]string, 0, len(dnsProviders))
+	for id := range dnsProviders {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	return ids
+}
+
+// missingKeys returns the credential keys that are missing for the alternative that is closest to being complete, or
+// nil when the credentials are complete.
+func (p dnsProvider) missingKeys(credentials map[string]string) []string {
+	var missing []string
+
+	for _, keys := range p.RequiredKeys {
+		var alternativeMissing []string
+		for _, key := range keys {
+			if _, ok := credentials[key]; !ok {
+				alternativeMissing = append(alternativeMissing, key)
+			}
+		}
+
+		if len(alternativeMissing) == 0 {
+			return nil
+		}
+		if missing == nil || len(alternativeMissing) < len(missing) {
+			missing = alternativeMissing
+		}
+	}
+
+	return missing
+}
+
+// validateCredentials adds an error to the attribute when the credentials are missing keys the provider requires.
+func (p dnsProvider) validateCredentials(attributePath path.Path, credentials map[string]string, diags *diag.Diagnostics) {
+	if missing := p.missingKeys(credentials); len(missing) > 0 {
+		diags.AddAttributeError(attributePath, "Missing DNS Credentials", fmt.Sprintf("The credentials for %s are missing the following keys: %s.", p.Name, strings.Join(missing, ", ")))
+	}
+}
+
+// dnsCredentialsValidators validates that the credentials render to one INI line each, so a value cannot add other
+// credentials to the file passed to certbot.
+func dnsCredentialsValidators() []validator.Map {
+	return []validator.Map{
+		mapvalidator.KeysAre(stringvalidator.RegexMatches(dnsCredentialKeyRegex, "must not be empty, start with `#` or `;`, or contain `=`, whitespace or line breaks")),
+		mapvalidator.ValueStringsAre(stringvalidator.RegexMatches(dnsCredentialValueRegex, "must not contain line breaks")),
+	}
+}
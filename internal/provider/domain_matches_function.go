@@ -0,0 +1,53 @@
+// Copyright (c) Sander Jochems
+// SPDX-License-Identifier: MIT
+
+package provider
+
+import (
+	"context"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/sander0542/terraform-provider-nginxproxymanager/internal/provider/models"
+)
+
+var _ function.Function = &DomainMatchesFunction{}
+
+func NewDomainMatchesFunction() function.Function {
+	return &DomainMatchesFunction{}
+}
+
+type DomainMatchesFunction struct{}
+
+func (f *DomainMatchesFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "domain_matches"
+}
+
+func (f *DomainMatchesFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:             "SSL Certificates --- Returns whether a domain name is matched by a certificate domain name.",
+		MarkdownDescription: "SSL Certificates --- Returns whether a domain name is matched by a certificate domain name, using the same rules as the provider. Domain names are compared ignoring case and internationalized domain names are compared by their punycode. A wildcard label matches exactly one label, so `*.example.com` matches `app.example.com`, but neither `example.com` nor `a.b.example.com`.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "pattern",
+				MarkdownDescription: "The domain name of the certificate, which can start with a wildcard label.",
+			},
+			function.StringParameter{
+				Name:                "domain",
+				MarkdownDescription: "The domain name to match.",
+			},
+		},
+		Return: function.BoolReturn{},
+	}
+}
+
+func (f *DomainMatchesFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var pattern string
+	var domainName string
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, req.Arguments.Get(ctx, &pattern, &domainName))
+
+	if resp.Error != nil {
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, models.DomainMatches(pattern, domainName)))
+}
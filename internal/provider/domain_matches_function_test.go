@@ -0,0 +1,54 @@
+// Copyright (c) Sander Jochems
+// SPDX-License-Identifier: MIT
+
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func TestDomainMatchesFunction(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+output "apex" {
+	value = provider::nginxproxymanager::domain_matches("*.example.com", "example.com")
+}
+
+output "single_label" {
+	value = provider::nginxproxymanager::domain_matches("*.example.com", "a.example.com")
+}
+
+output "several_labels" {
+	value = provider::nginxproxymanager::domain_matches("*.example.com", "a.b.example.com")
+}
+
+output "wildcard" {
+	value = provider::nginxproxymanager::domain_matches("*.example.com", "*.example.com")
+}
+
+output "normalized" {
+	value = provider::nginxproxymanager::domain_matches("xn--bcher-kva.example.com", "Bücher.Example.com.")
+}
+`,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownOutputValue("apex", knownvalue.Bool(false)),
+					statecheck.ExpectKnownOutputValue("single_label", knownvalue.Bool(true)),
+					statecheck.ExpectKnownOutputValue("several_labels", knownvalue.Bool(false)),
+					statecheck.ExpectKnownOutputValue("wildcard", knownvalue.Bool(true)),
+					statecheck.ExpectKnownOutputValue("normalized", knownvalue.Bool(true)),
+				},
+			},
+		},
+	})
+}
@@ -0,0 +1,14 @@
+// Copyright (c) Sander Jochems
+// SPDX-License-Identifier: MIT
+
+package provider
+
+import (
+	"regexp"
+)
+
+// domainNameRegex matches a domain name with at least two labels, optionally starting with a wildcard label.
+var domainNameRegex = regexp.MustCompile(`^(\*\.)?([a-zA-Z0-9]([a-zA-Z0-9-]{0,61}[a-zA-Z0-9])?\.)+[a-zA-Z0-9]([a-zA-Z0-9-]{0,61}[a-zA-Z0-9])?$`)
+
+// maxCertificateDomainNames is the maximum number of domain names in a Let's Encrypt certificate.
+const maxCertificateDomainNames = 100
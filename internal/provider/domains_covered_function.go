@@ -0,0 +1,56 @@
+// Copyright (c) Sander Jochems
+// SPDX-License-Identifier: MIT
+
+package provider
+
+import (
+	"context"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/sander0542/terraform-provider-nginxproxymanager/internal/provider/models"
+)
+
+var _ function.Function = &DomainsCoveredFunction{}
+
+func NewDomainsCoveredFunction() function.Function {
+	return &DomainsCoveredFunction{}
+}
+
+type DomainsCoveredFunction struct{}
+
+func (f *DomainsCoveredFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "domains_covered"
+}
+
+func (f *DomainsCoveredFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:             "SSL Certificates --- Returns whether a certificate covers all domain names of a host.",
+		MarkdownDescription: "SSL Certificates --- Returns whether every domain name of a host is matched by any of the domain names of a certificate, using the same rules as `domain_matches`.",
+		Parameters: []function.Parameter{
+			function.ListParameter{
+				Name:                "certificate_domains",
+				MarkdownDescription: "The domain names of the certificate.",
+				ElementType:         types.StringType,
+			},
+			function.ListParameter{
+				Name:                "host_domains",
+				MarkdownDescription: "The domain names of the host.",
+				ElementType:         types.StringType,
+			},
+		},
+		Return: function.BoolReturn{},
+	}
+}
+
+func (f *DomainsCoveredFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var certificateDomainNames []string
+	var hostDomainNames []string
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, req.Arguments.Get(ctx, &certificateDomainNames, &hostDomainNames))
+
+	if resp.Error != nil {
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, models.DomainsCovered(certificateDomainNames, hostDomainNames)))
+}
@@ -0,0 +1,49 @@
+// Copyright (c) Sander Jochems
+// SPDX-License-Identifier: MIT
+
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func TestDomainsCoveredFunction(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+output "covered" {
+	value = provider::nginxproxymanager::domains_covered(["example.com", "*.example.com"], ["example.com", "www.example.com"])
+}
+
+output "wildcard_without_apex" {
+	value = provider::nginxproxymanager::domains_covered(["*.example.com"], ["example.com", "www.example.com"])
+}
+
+output "wildcard_too_deep" {
+	value = provider::nginxproxymanager::domains_covered(["*.example.com"], ["a.b.example.com"])
+}
+
+output "empty" {
+	value = provider::nginxproxymanager::domains_covered(["example.com"], [])
+}
+`,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownOutputValue("covered", knownvalue.Bool(true)),
+					statecheck.ExpectKnownOutputValue("wildcard_without_apex", knownvalue.Bool(false)),
+					statecheck.ExpectKnownOutputValue("wildcard_too_deep", knownvalue.Bool(false)),
+					statecheck.ExpectKnownOutputValue("empty", knownvalue.Bool(true)),
+				},
+			},
+		},
+	})
+}
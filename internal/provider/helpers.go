@@ -4,11 +4,22 @@
 package provider
 
 import (
+	"archive/zip"
+	"bytes"
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework/action"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/sander0542/nginxproxymanager-go"
+	"io"
+	"net/http"
+	"path/filepath"
+	"strings"
 )
 
 func resourceConfigure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) *NginxProxyManagerProviderData {
@@ -67,3 +78,168 @@ func ephemeralResourceConfigure(_ context.Context, req ephemeral.ConfigureReques
 
 	return providerData
 }
+
+func actionConfigure(_ context.Context, req action.ConfigureRequest, resp *action.ConfigureResponse) *NginxProxyManagerProviderData {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return nil
+	}
+
+	providerData, ok := req.ProviderData.(*NginxProxyManagerProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Action Configure Type",
+			fmt.Sprintf("Expected *NginxProxyManagerProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return nil
+	}
+
+	return providerData
+}
+
+// ownerAuth returns the authentication context to create an object with.
+// Nginx Proxy Manager assigns ownership to the authenticated user, so when an
+// owner is configured the object is created through an impersonation token.
+func ownerAuth(client *nginxproxymanager.APIClient, auth context.Context, ownerUserId types.Int64) (context.Context, error) {
+	if ownerUserId.IsNull() || ownerUserId.IsUnknown() {
+		return auth, nil
+	}
+
+	login, _, err := client.UsersAPI.LoginAsUser(auth, ownerUserId.ValueInt64()).Execute()
+	if err != nil {
+		return nil, err
+	}
+
+	return context.WithValue(auth, nginxproxymanager.ContextAccessToken, login.GetToken()), nil
+}
+
+// testHttpReach asks Nginx Proxy Manager to test whether the domains can be reached over HTTP, returning the result per domain.
+func testHttpReach(client *nginxproxymanager.APIClient, auth context.Context, domainNames []string) (map[string]string, error) {
+	domains, err := json.Marshal(domainNames)
+	if err != nil {
+		return nil, err
+	}
+
+	response, _, err := client.CertificatesAPI.TestHttpReach(auth).Domains(string(domains)).Execute()
+	if err != nil {
+		return nil, err
+	}
+
+	results := make(map[string]string, len(response))
+	for domainName, result := range response {
+		results[domainName] = fmt.Sprintf("%v", result)
+	}
+
+	return results, nil
+}
+
+// describeHttpReachResult returns whether the result of the HTTP reachability test is successful and a description of the result.
+func describeHttpReachResult(result string) (bool, string) {
+	switch {
+	case result == "ok":
+		return true, "the domain is reachable"
+	case result == "no-host":
+		return false, "no server was found for the domain"
+	case result == "failed":
+		return false, "the server could not be reached"
+	case result == "404":
+		return false, "the server responded with 404 Not Found"
+	case result == "wrong-data":
+		return false, "the server responded with unexpected data, check that the domain points to this Nginx Proxy Manager"
+	case strings.HasPrefix(result, "other:"):
+		return false, fmt.Sprintf("the server responded with status code %s", strings.TrimPrefix(result, "other:"))
+	case result == "":
+		return false, "no result was returned for the domain"
+	default:
+		return false, result
+	}
+}
+
+// apiRequest sends a request to an endpoint of Nginx Proxy Manager that is not covered by the client, such as file
+// uploads and downloads, returning the body of the response.
+func apiRequest(ctx context.Context, client *nginxproxymanager.APIClient, auth context.Context, method string, urlPath string, body io.Reader, contentType string) ([]byte, error) {
+	config := client.GetConfig()
+	request, err := http.NewRequestWithContext(ctx, method, config.Servers[0].URL+urlPath, body)
+	if err != nil {
+		return nil, err
+	}
+	if contentType != "" {
+		request.Header.Set("Content-Type", contentType)
+	}
+	if config.UserAgent != "" {
+		request.Header.Set("User-Agent", config.UserAgent)
+	}
+	if token, ok := auth.Value(nginxproxymanager.ContextAccessToken).(string); ok {
+		request.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	httpClient := config.HTTPClient
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+
+	response, err := httpClient.Do(request)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+
+	responseBody, err := io.ReadAll(response.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	if response.StatusCode >= 300 {
+		var apiError struct {
+			Error struct {
+				Message string `json:"message"`
+			} `json:"error"`
+		}
+		if json.Unmarshal(responseBody, &apiError) == nil && apiError.Error.Message != "" {
+			return nil, fmt.Errorf("%s: %s", response.Status, apiError.Error.Message)
+		}
+
+		return nil, errors.New(response.Status)
+	}
+
+	return responseBody, nil
+}
+
+// downloadCertificate downloads the files of a certificate, returning the PEM encoded contents keyed by the name of the
+// file without its sequence number and extension (cert, chain, fullchain and privkey).
+func downloadCertificate(ctx context.Context, client *nginxproxymanager.APIClient, auth context.Context, id int64) (map[string]string, error) {
+	body, err := apiRequest(ctx, client, auth, http.MethodGet, fmt.Sprintf("/nginx/certificates/%d/download", id), nil, "")
+	if err != nil {
+		return nil, err
+	}
+
+	archive, err := zip.NewReader(bytes.NewReader(body), int64(len(body)))
+	if err != nil {
+		return nil, fmt.Errorf("unable to read certificate archive: %w", err)
+	}
+
+	files := map[string]string{}
+	for _, file := range archive.File {
+		// Certbot numbers the files in the archive for every renewal, e.g. fullchain3.pem.
+		name := strings.TrimRight(strings.TrimSuffix(filepath.Base(file.Name), ".pem"), "0123456789")
+
+		reader, err := file.Open()
+		if err != nil {
+			return nil, fmt.Errorf("unable to read %s from certificate archive: %w", file.Name, err)
+		}
+		content, err := io.ReadAll(reader)
+		_ = reader.Close()
+		if err != nil {
+			return nil, fmt.Errorf("unable to read %s from certificate archive: %w", file.Name, err)
+		}
+
+		files[name] = string(content)
+	}
+
+	if files["cert"] == "" {
+		return nil, errors.New("the certificate archive does not contain a certificate")
+	}
+
+	return files, nil
+}
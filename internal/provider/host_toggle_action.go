@@ -0,0 +1,113 @@
+// Copyright (c) Sander Jochems
+// SPDX-License-Identifier: MIT
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/action"
+	"github.com/hashicorp/terraform-plugin-framework/action/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/sander0542/nginxproxymanager-go"
+	"github.com/sander0542/terraform-provider-nginxproxymanager/internal/provider/models"
+)
+
+var _ action.Action = &HostToggleAction{}
+var _ action.ActionWithConfigure = &HostToggleAction{}
+
+func NewHostToggleAction() action.Action {
+	return &HostToggleAction{}
+}
+
+type HostToggleAction struct {
+	client *nginxproxymanager.APIClient
+	auth   context.Context
+}
+
+func (a *HostToggleAction) Metadata(ctx context.Context, req action.MetadataRequest, resp *action.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_host_toggle"
+}
+
+func (a *HostToggleAction) Schema(ctx context.Context, req action.SchemaRequest, resp *action.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Hosts --- This action can be used to enable or disable a proxy host, redirection host, 404 host or stream.",
+		Attributes: map[string]schema.Attribute{
+			"host_type": schema.StringAttribute{
+				MarkdownDescription: "The type of the host. Must be one of `proxy_host`, `redirection_host`, `dead_host` or `stream`.",
+				Required:            true,
+				Validators: []validator.String{
+					stringvalidator.OneOf("proxy_host", "redirection_host", "dead_host", "stream"),
+				},
+			},
+			"host_id": schema.Int64Attribute{
+				MarkdownDescription: "The Id of the host.",
+				Required:            true,
+			},
+			"enabled": schema.BoolAttribute{
+				MarkdownDescription: "Whether the host should be enabled.",
+				Required:            true,
+			},
+		},
+	}
+}
+
+func (a *HostToggleAction) Configure(ctx context.Context, req action.ConfigureRequest, resp *action.ConfigureResponse) {
+	if data := actionConfigure(ctx, req, resp); data != nil {
+		a.client = data.Client
+		a.auth = data.Auth
+	}
+}
+
+func (a *HostToggleAction) Invoke(ctx context.Context, req action.InvokeRequest, resp *action.InvokeResponse) {
+	var data *models.HostToggleAction
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	hostId := data.HostId.ValueInt64()
+	desired := data.Enabled.ValueBool()
+
+	var err error
+	switch data.HostType.ValueString() {
+	case "proxy_host":
+		proxyHost, _, getErr := a.client.ProxyHostsAPI.GetProxyHost(a.auth, hostId).Execute()
+		if err = getErr; err == nil {
+			err = toggleProxyHost(a.client, a.auth, hostId, proxyHost.GetEnabled(), desired)
+		}
+	case "redirection_host":
+		redirectionHost, _, getErr := a.client.RedirectionHostsAPI.GetRedirectionHost(a.auth, hostId).Execute()
+		if err = getErr; err == nil {
+			err = toggleRedirectionHost(a.client, a.auth, hostId, redirectionHost.GetEnabled(), desired)
+		}
+	case "dead_host":
+		deadHost, _, getErr := a.client.Class404HostsAPI.GetDeadHost(a.auth, hostId).Execute()
+		if err = getErr; err == nil {
+			err = toggleDeadHost(a.client, a.auth, hostId, deadHost.GetEnabled(), desired)
+		}
+	case "stream":
+		stream, _, getErr := a.client.StreamsAPI.GetStream(a.auth, hostId).Execute()
+		if err = getErr; err == nil {
+			err = toggleStream(a.client, a.auth, hostId, stream.GetEnabled(), desired)
+		}
+	}
+
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("enabled"), "Client Error", fmt.Sprintf("Unable to toggle host, got error: %s", err))
+		return
+	}
+
+	state := "disabled"
+	if desired {
+		state = "enabled"
+	}
+
+	resp.SendProgress(action.InvokeProgressEvent{
+		Message: fmt.Sprintf("The %s %d is %s", data.HostType.ValueString(), hostId, state),
+	})
+}
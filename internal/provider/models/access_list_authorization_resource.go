@@ -26,7 +26,7 @@ func (m *authorizationCredential) matches(username string, passwordHint string)
 		return false
 	}
 
-	if len(m.Password) != len(passwordHint) {
+	if m.Password == "" || len(m.Password) != len(passwordHint) {
 		return false
 	}
 
@@ -23,6 +23,11 @@ type AccessListResource struct {
 	Access         types.Set    `tfsdk:"access"`
 	PassAuth       types.Bool   `tfsdk:"pass_auth"`
 	SatisfyAny     types.Bool   `tfsdk:"satisfy_any"`
+
+	AuthorizationPasswordsWo        types.Map   `tfsdk:"authorization_passwords_wo"`
+	AuthorizationPasswordsWoVersion types.Int64 `tfsdk:"authorization_passwords_wo_version"`
+
+	ForceDetach types.Bool `tfsdk:"force_detach"`
 }
 
 func (AccessListResource) GetType() attr.Type {
@@ -37,12 +42,21 @@ func (AccessListResource) GetType() attr.Type {
 		"access":         types.SetType{ElemType: AccessListAccessResource{}.GetType()},
 		"pass_auth":      types.BoolType,
 		"satisfy_any":    types.BoolType,
+
+		"authorization_passwords_wo":         types.MapType{ElemType: types.StringType},
+		"authorization_passwords_wo_version": types.Int64Type,
+
+		"force_detach": types.BoolType,
 	})
 }
 
 func (m *AccessListResource) Write(ctx context.Context, accessList *nginxproxymanager.CreateAccessList201Response, diags *diag.Diagnostics) {
 	var tmpDiags diag.Diagnostics
 
+	if m.ForceDetach.IsNull() {
+		m.ForceDetach = types.BoolValue(false)
+	}
+
 	m.Id = types.Int64Value(accessList.GetId())
 	m.CreatedOn = types.StringValue(accessList.GetCreatedOn())
 	m.ModifiedOn = types.StringValue(accessList.GetModifiedOn())
@@ -79,6 +93,9 @@ func (m *AccessListResource) ToCreateRequest(ctx context.Context, diags *diag.Di
 	requestAuthorizations := make([]nginxproxymanager.CreateAccessListRequestItemsInner, 0, len(authorizations))
 	for _, authorization := range authorizations {
 		item := authorization.Read(ctx, diags)
+		if authorization.Password.IsNull() {
+			item.SetPassword(m.authorizationPasswordWo(authorization.Username.ValueString()))
+		}
 		requestAuthorizations = append(requestAuthorizations, *item)
 	}
 	request.SetItems(requestAuthorizations)
@@ -109,6 +126,9 @@ func (m *AccessListResource) ToUpdateRequest(ctx context.Context, diags *diag.Di
 	requestAuthorizations := make([]nginxproxymanager.CreateAccessListRequestItemsInner, 0, len(authorizations))
 	for _, authorization := range authorizations {
 		item := authorization.Read(ctx, diags)
+		if authorization.Password.IsNull() {
+			item.SetPassword(m.authorizationPasswordWo(authorization.Username.ValueString()))
+		}
 		requestAuthorizations = append(requestAuthorizations, *item)
 	}
 	request.SetItems(requestAuthorizations)
@@ -122,3 +142,13 @@ func (m *AccessListResource) ToUpdateRequest(ctx context.Context, diags *diag.Di
 
 	return request
 }
+
+// authorizationPasswordWo returns the write-only password of the authorization item with the username.
+func (m *AccessListResource) authorizationPasswordWo(username string) string {
+	password, ok := m.AuthorizationPasswordsWo.Elements()[username].(types.String)
+	if !ok {
+		return ""
+	}
+
+	return password.ValueString()
+}
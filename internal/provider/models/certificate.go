@@ -22,6 +22,8 @@ type Certificate struct {
 	NiceName    types.String `tfsdk:"nice_name"`
 	DomainNames types.Set    `tfsdk:"domain_names"`
 	ExpiresOn   types.String `tfsdk:"expires_on"`
+
+	DaysUntilExpiry types.Int64 `tfsdk:"days_until_expiry"`
 }
 
 func (Certificate) GetType() attr.Type {
@@ -35,6 +37,8 @@ func (Certificate) GetType() attr.Type {
 		"nice_name":     types.StringType,
 		"domain_names":  types.SetType{ElemType: types.StringType},
 		"expires_on":    types.StringType,
+
+		"days_until_expiry": types.Int64Type,
 	})
 }
 
@@ -49,6 +53,7 @@ func (m *Certificate) Write(ctx context.Context, certificate *nginxproxymanager.
 	m.Provider = types.StringValue(certificate.GetProvider())
 	m.NiceName = types.StringValue(certificate.GetNiceName())
 	m.ExpiresOn = types.StringValue(certificate.GetExpiresOn())
+	m.DaysUntilExpiry = DaysUntil(certificate.GetExpiresOn())
 
 	m.DomainNames, tmpDiags = SetDomainNamesFrom(ctx, certificate.GetDomainNames())
 	diags.Append(tmpDiags...)
@@ -5,6 +5,7 @@ package models
 
 import (
 	"context"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/types"
@@ -22,6 +23,14 @@ type CertificateCustom struct {
 	CertificateKey types.String `tfsdk:"certificate_key"`
 	DomainNames    types.Set    `tfsdk:"domain_names"`
 	ExpiresOn      types.String `tfsdk:"expires_on"`
+
+	IntermediateCertificate types.String `tfsdk:"intermediate_certificate"`
+	CertificateKeyWo        types.String `tfsdk:"certificate_key_wo"`
+	CertificateKeyWoVersion types.Int64  `tfsdk:"certificate_key_wo_version"`
+
+	Timeouts timeouts.Value `tfsdk:"timeouts"`
+
+	ForceDetach types.Bool `tfsdk:"force_detach"`
 }
 
 func (CertificateCustom) GetType() attr.Type {
@@ -35,12 +44,27 @@ func (CertificateCustom) GetType() attr.Type {
 		"certificate_key": types.StringType,
 		"domain_names":    types.SetType{ElemType: types.StringType},
 		"expires_on":      types.StringType,
+
+		"intermediate_certificate":   types.StringType,
+		"certificate_key_wo":         types.StringType,
+		"certificate_key_wo_version": types.Int64Type,
+
+		"timeouts": timeouts.Type{ObjectType: types.ObjectType{AttrTypes: map[string]attr.Type{
+			"create": types.StringType,
+			"delete": types.StringType,
+		}}},
+
+		"force_detach": types.BoolType,
 	})
 }
 
 func (m *CertificateCustom) Write(ctx context.Context, certificate *nginxproxymanager.GetCertificates200ResponseInner, diags *diag.Diagnostics) {
 	var tmpDiags diag.Diagnostics
 
+	if m.ForceDetach.IsNull() {
+		m.ForceDetach = types.BoolValue(false)
+	}
+
 	m.Id = types.Int64Value(certificate.GetId())
 	m.CreatedOn = types.StringValue(certificate.GetCreatedOn())
 	m.ModifiedOn = types.StringValue(certificate.GetModifiedOn())
@@ -53,21 +77,41 @@ func (m *CertificateCustom) Write(ctx context.Context, certificate *nginxproxyma
 	} else {
 		m.Certificate = types.StringNull()
 	}
-	if meta.HasCertificateKey() {
+	// The certificate key is not stored in the state when it is set through the write-only attribute.
+	if meta.HasCertificateKey() && m.CertificateKeyWoVersion.IsNull() {
 		m.CertificateKey = types.StringValue(meta.GetCertificateKey())
 	} else {
 		m.CertificateKey = types.StringNull()
 	}
+	if meta.HasIntermediateCertificate() {
+		m.IntermediateCertificate = types.StringValue(meta.GetIntermediateCertificate())
+	} else {
+		m.IntermediateCertificate = types.StringNull()
+	}
 	m.ExpiresOn = types.StringValue(certificate.GetExpiresOn())
 
 	m.DomainNames, tmpDiags = SetDomainNamesFrom(ctx, certificate.GetDomainNames())
 	diags.Append(tmpDiags...)
 }
 
+// GetCertificateKey returns the certificate key, preferring the write-only certificate key when it is set.
+func (m *CertificateCustom) GetCertificateKey() types.String {
+	if !m.CertificateKeyWo.IsNull() {
+		return m.CertificateKeyWo
+	}
+
+	return m.CertificateKey
+}
+
 func (m *CertificateCustom) ToCreateRequest(ctx context.Context, diags *diag.Diagnostics) *nginxproxymanager.CreateCertificateRequest {
 	request := nginxproxymanager.NewCreateCertificateRequest("other")
 
 	request.SetNiceName(m.Name.ValueString())
 
+	// The domain names are stored right away, so the certificate can be recognized before its files are uploaded.
+	if certificates, err := ParseCertificates(m.Certificate.ValueString()); err == nil {
+		request.SetDomainNames(CertificateDomainNames(certificates[0]))
+	}
+
 	return request
 }
@@ -0,0 +1,74 @@
+// Copyright (c) Sander Jochems
+// SPDX-License-Identifier: MIT
+
+package models
+
+import (
+	"context"
+	"crypto"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/hex"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"time"
+)
+
+type CertificateInfo struct {
+	Subject                 types.String `tfsdk:"subject"`
+	Issuer                  types.String `tfsdk:"issuer"`
+	SerialNumber            types.String `tfsdk:"serial_number"`
+	SubjectAlternativeNames types.List   `tfsdk:"subject_alternative_names"`
+	NotBefore               types.String `tfsdk:"not_before"`
+	NotAfter                types.String `tfsdk:"not_after"`
+	Sha1Fingerprint         types.String `tfsdk:"sha1_fingerprint"`
+	Sha256Fingerprint       types.String `tfsdk:"sha256_fingerprint"`
+	KeyAlgorithm            types.String `tfsdk:"key_algorithm"`
+	IsCa                    types.Bool   `tfsdk:"is_ca"`
+	KeyMatches              types.Bool   `tfsdk:"key_matches"`
+}
+
+func (CertificateInfo) GetType() attr.Type {
+	return types.ObjectType{}.WithAttributeTypes(map[string]attr.Type{
+		"subject":                   types.StringType,
+		"issuer":                    types.StringType,
+		"serial_number":             types.StringType,
+		"subject_alternative_names": types.ListType{ElemType: types.StringType},
+		"not_before":                types.StringType,
+		"not_after":                 types.StringType,
+		"sha1_fingerprint":          types.StringType,
+		"sha256_fingerprint":        types.StringType,
+		"key_algorithm":             types.StringType,
+		"is_ca":                     types.BoolType,
+		"key_matches":               types.BoolType,
+	})
+}
+
+// Write describes the certificate. KeyMatches is only known when a private key is given.
+func (m *CertificateInfo) Write(ctx context.Context, certificate *x509.Certificate, key crypto.Signer, diags *diag.Diagnostics) {
+	var tmpDiags diag.Diagnostics
+
+	sha1Fingerprint := sha1.Sum(certificate.Raw)
+	sha256Fingerprint := sha256.Sum256(certificate.Raw)
+
+	m.Subject = types.StringValue(certificate.Subject.String())
+	m.Issuer = types.StringValue(certificate.Issuer.String())
+	m.SerialNumber = types.StringValue(certificate.SerialNumber.Text(16))
+	m.NotBefore = types.StringValue(certificate.NotBefore.UTC().Format(time.RFC3339))
+	m.NotAfter = types.StringValue(certificate.NotAfter.UTC().Format(time.RFC3339))
+	m.Sha1Fingerprint = types.StringValue(hex.EncodeToString(sha1Fingerprint[:]))
+	m.Sha256Fingerprint = types.StringValue(hex.EncodeToString(sha256Fingerprint[:]))
+	m.KeyAlgorithm = types.StringValue(certificate.PublicKeyAlgorithm.String())
+	m.IsCa = types.BoolValue(certificate.BasicConstraintsValid && certificate.IsCA)
+
+	if key != nil {
+		m.KeyMatches = types.BoolValue(PrivateKeyMatches(certificate, key))
+	} else {
+		m.KeyMatches = types.BoolNull()
+	}
+
+	m.SubjectAlternativeNames, tmpDiags = types.ListValueFrom(ctx, types.StringType, CertificateSubjectAlternativeNames(certificate))
+	diags.Append(tmpDiags...)
+}
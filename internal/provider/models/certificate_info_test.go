@@ -0,0 +1,125 @@
+// Copyright (c) Sander Jochems
+// SPDX-License-Identifier: MIT
+
+package models
+
+import (
+	"context"
+	"crypto"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/hex"
+	"net"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestCertificateInfoWrite(t *testing.T) {
+	rootKey := testEcKey(t)
+	root := testCertificate(t, testCaTemplate("Root"), rootKey, nil)
+
+	leafKey := testRsaKey(t)
+	leaf := testCertificate(t, &x509.Certificate{
+		Subject:     pkix.Name{CommonName: "example.com"},
+		DNSNames:    []string{"example.com", "*.example.com"},
+		IPAddresses: []net.IP{net.ParseIP("192.0.2.1")},
+	}, leafKey, &testIssuer{root, rootKey})
+
+	tests := map[string]struct {
+		certificate             *x509.Certificate
+		key                     crypto.Signer
+		subject                 string
+		issuer                  string
+		subjectAlternativeNames []string
+		keyAlgorithm            string
+		isCa                    bool
+		keyMatches              types.Bool
+	}{
+		"leaf-without-key": {
+			certificate:             leaf,
+			subject:                 "CN=example.com",
+			issuer:                  "CN=Root",
+			subjectAlternativeNames: []string{"example.com", "*.example.com", "192.0.2.1"},
+			keyAlgorithm:            "RSA",
+			isCa:                    false,
+			keyMatches:              types.BoolNull(),
+		},
+		"leaf-matching-key": {
+			certificate:             leaf,
+			key:                     leafKey,
+			subject:                 "CN=example.com",
+			issuer:                  "CN=Root",
+			subjectAlternativeNames: []string{"example.com", "*.example.com", "192.0.2.1"},
+			keyAlgorithm:            "RSA",
+			isCa:                    false,
+			keyMatches:              types.BoolValue(true),
+		},
+		"leaf-other-key": {
+			certificate:             leaf,
+			key:                     rootKey,
+			subject:                 "CN=example.com",
+			issuer:                  "CN=Root",
+			subjectAlternativeNames: []string{"example.com", "*.example.com", "192.0.2.1"},
+			keyAlgorithm:            "RSA",
+			isCa:                    false,
+			keyMatches:              types.BoolValue(false),
+		},
+		"ca": {
+			certificate:             root,
+			key:                     rootKey,
+			subject:                 "CN=Root",
+			issuer:                  "CN=Root",
+			subjectAlternativeNames: []string{},
+			keyAlgorithm:            "ECDSA",
+			isCa:                    true,
+			keyMatches:              types.BoolValue(true),
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			var diags diag.Diagnostics
+			var info CertificateInfo
+
+			info.Write(context.Background(), test.certificate, test.key, &diags)
+
+			if diags.HasError() {
+				t.Fatalf("unexpected diagnostics: %v", diags)
+			}
+
+			if actual := info.Subject.ValueString(); actual != test.subject {
+				t.Errorf("expected subject %q, got %q", test.subject, actual)
+			}
+			if actual := info.Issuer.ValueString(); actual != test.issuer {
+				t.Errorf("expected issuer %q, got %q", test.issuer, actual)
+			}
+			if actual := info.SerialNumber.ValueString(); actual != test.certificate.SerialNumber.Text(16) {
+				t.Errorf("expected serial number %q, got %q", test.certificate.SerialNumber.Text(16), actual)
+			}
+
+			var subjectAlternativeNames []string
+			diags.Append(info.SubjectAlternativeNames.ElementsAs(context.Background(), &subjectAlternativeNames, false)...)
+			if !reflect.DeepEqual(subjectAlternativeNames, test.subjectAlternativeNames) {
+				t.Errorf("expected subject alternative names %v, got %v", test.subjectAlternativeNames, subjectAlternativeNames)
+			}
+
+			fingerprint := sha256.Sum256(test.certificate.Raw)
+			if actual := info.Sha256Fingerprint.ValueString(); actual != hex.EncodeToString(fingerprint[:]) {
+				t.Errorf("expected SHA-256 fingerprint %q, got %q", hex.EncodeToString(fingerprint[:]), actual)
+			}
+			if actual := info.KeyAlgorithm.ValueString(); actual != test.keyAlgorithm {
+				t.Errorf("expected key algorithm %q, got %q", test.keyAlgorithm, actual)
+			}
+			if actual := info.IsCa.ValueBool(); actual != test.isCa {
+				t.Errorf("expected is_ca %t, got %t", test.isCa, actual)
+			}
+			if !info.KeyMatches.Equal(test.keyMatches) {
+				t.Errorf("expected key_matches %s, got %s", test.keyMatches, info.KeyMatches)
+			}
+		})
+	}
+}
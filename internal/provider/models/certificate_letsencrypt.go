@@ -5,10 +5,12 @@ package models
 
 import (
 	"context"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/sander0542/nginxproxymanager-go"
+	"time"
 )
 
 type CertificateLetsencrypt struct {
@@ -24,7 +26,17 @@ type CertificateLetsencrypt struct {
 	DnsChallenge           types.Bool   `tfsdk:"dns_challenge"`
 	DnsProvider            types.String `tfsdk:"dns_provider"`
 	DnsProviderCredentials types.String `tfsdk:"dns_provider_credentials"`
+	DnsCredentials         types.Map    `tfsdk:"dns_credentials"`
 	PropagationSeconds     types.Int64  `tfsdk:"propagation_seconds"`
+	RenewBeforeDays        types.Int64  `tfsdk:"renew_before_days"`
+	PreflightHttpCheck     types.Bool   `tfsdk:"preflight_http_check"`
+
+	DnsProviderCredentialsWo        types.String `tfsdk:"dns_provider_credentials_wo"`
+	DnsProviderCredentialsWoVersion types.Int64  `tfsdk:"dns_provider_credentials_wo_version"`
+
+	Timeouts timeouts.Value `tfsdk:"timeouts"`
+
+	ForceDetach types.Bool `tfsdk:"force_detach"`
 }
 
 func (CertificateLetsencrypt) GetType() attr.Type {
@@ -40,13 +52,30 @@ func (CertificateLetsencrypt) GetType() attr.Type {
 		"dns_challenge":            types.BoolType,
 		"dns_provider":             types.StringType,
 		"dns_provider_credentials": types.StringType,
+		"dns_credentials":          types.MapType{ElemType: types.StringType},
 		"propagation_seconds":      types.Int64Type,
+		"renew_before_days":        types.Int64Type,
+		"preflight_http_check":     types.BoolType,
+
+		"dns_provider_credentials_wo":         types.StringType,
+		"dns_provider_credentials_wo_version": types.Int64Type,
+
+		"timeouts": timeouts.Type{ObjectType: types.ObjectType{AttrTypes: map[string]attr.Type{
+			"create": types.StringType,
+			"delete": types.StringType,
+		}}},
+
+		"force_detach": types.BoolType,
 	})
 }
 
 func (m *CertificateLetsencrypt) Write(ctx context.Context, certificate *nginxproxymanager.GetCertificates200ResponseInner, diags *diag.Diagnostics) {
 	var tmpDiags diag.Diagnostics
 
+	if m.ForceDetach.IsNull() {
+		m.ForceDetach = types.BoolValue(false)
+	}
+
 	m.Id = types.Int64Value(certificate.GetId())
 	m.CreatedOn = types.StringValue(certificate.GetCreatedOn())
 	m.ModifiedOn = types.StringValue(certificate.GetModifiedOn())
@@ -76,8 +105,9 @@ func (m *CertificateLetsencrypt) Write(ctx context.Context, certificate *nginxpr
 	}
 	// DnsProviderCredentials and PropagationSeconds are write-only: NPM never
 	// returns them in read responses. Preserve the existing state value so
-	// Terraform does not see an inconsistent result after apply.
-	if meta.HasDnsProviderCredentials() {
+	// Terraform does not see an inconsistent result after apply. The credentials
+	// are never stored when they are set through the write-only attribute.
+	if meta.HasDnsProviderCredentials() && m.DnsProviderCredentialsWoVersion.IsNull() {
 		m.DnsProviderCredentials = types.StringValue(meta.GetDnsProviderCredentials())
 	}
 	if meta.HasPropagationSeconds() {
@@ -88,6 +118,37 @@ func (m *CertificateLetsencrypt) Write(ctx context.Context, certificate *nginxpr
 	diags.Append(tmpDiags...)
 }
 
+// RenewalDue returns whether the certificate expires within the renew_before_days window.
+func (m *CertificateLetsencrypt) RenewalDue() bool {
+	if m.RenewBeforeDays.IsNull() || m.RenewBeforeDays.IsUnknown() || m.ExpiresOn.IsNull() || m.ExpiresOn.IsUnknown() {
+		return false
+	}
+
+	expiresOn, err := ParseTime(m.ExpiresOn.ValueString())
+	if err != nil {
+		return false
+	}
+
+	return time.Until(expiresOn) < time.Duration(m.RenewBeforeDays.ValueInt64())*24*time.Hour
+}
+
+// GetDnsProviderCredentials returns the credentials for the DNS challenge in the INI format, from either the
+// write-only credentials, the structured credentials or the credentials.
+func (m *CertificateLetsencrypt) GetDnsProviderCredentials(ctx context.Context, diags *diag.Diagnostics) string {
+	if !m.DnsProviderCredentialsWo.IsNull() {
+		return m.DnsProviderCredentialsWo.ValueString()
+	}
+
+	if !m.DnsCredentials.IsNull() && !m.DnsCredentials.IsUnknown() {
+		credentials := map[string]string{}
+		diags.Append(m.DnsCredentials.ElementsAs(ctx, &credentials, false)...)
+
+		return RenderDnsCredentials(credentials)
+	}
+
+	return m.DnsProviderCredentials.ValueString()
+}
+
 func (m *CertificateLetsencrypt) ToCreateRequest(ctx context.Context, diags *diag.Diagnostics) *nginxproxymanager.CreateCertificateRequest {
 	domainNames, tmpDiags := DomainNameElementsAs(ctx, m.DomainNames)
 	diags.Append(tmpDiags...)
@@ -98,7 +159,7 @@ func (m *CertificateLetsencrypt) ToCreateRequest(ctx context.Context, diags *dia
 	meta.SetDnsChallenge(m.DnsChallenge.ValueBool())
 	if m.DnsChallenge.ValueBool() {
 		meta.SetDnsProvider(m.DnsProvider.ValueString())
-		meta.SetDnsProviderCredentials(m.DnsProviderCredentials.ValueString())
+		meta.SetDnsProviderCredentials(m.GetDnsProviderCredentials(ctx, diags))
 		if !m.PropagationSeconds.IsNull() {
 			meta.SetPropagationSeconds(m.PropagationSeconds.ValueInt64())
 		}
@@ -0,0 +1,14 @@
+// Copyright (c) Sander Jochems
+// SPDX-License-Identifier: MIT
+
+package models
+
+import "github.com/hashicorp/terraform-plugin-framework/types"
+
+// CertificateLookup is a certificate that is looked up by its Id, or by the domain name, name or provider.
+type CertificateLookup struct {
+	Certificate
+
+	Domain types.String `tfsdk:"domain"`
+	Name   types.String `tfsdk:"name"`
+}
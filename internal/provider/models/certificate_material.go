@@ -0,0 +1,59 @@
+// Copyright (c) Sander Jochems
+// SPDX-License-Identifier: MIT
+
+package models
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"time"
+)
+
+type CertificateMaterial struct {
+	Id types.Int64 `tfsdk:"id"`
+
+	Certificate types.String `tfsdk:"certificate"`
+	Chain       types.String `tfsdk:"chain"`
+	Fullchain   types.String `tfsdk:"fullchain"`
+
+	Subject                 types.String `tfsdk:"subject"`
+	Issuer                  types.String `tfsdk:"issuer"`
+	SerialNumber            types.String `tfsdk:"serial_number"`
+	SubjectAlternativeNames types.Set    `tfsdk:"subject_alternative_names"`
+	NotBefore               types.String `tfsdk:"not_before"`
+	NotAfter                types.String `tfsdk:"not_after"`
+	Sha256Fingerprint       types.String `tfsdk:"sha256_fingerprint"`
+	KeyAlgorithm            types.String `tfsdk:"key_algorithm"`
+}
+
+func (m *CertificateMaterial) Write(ctx context.Context, files map[string]string, diags *diag.Diagnostics) {
+	var tmpDiags diag.Diagnostics
+
+	m.Certificate = types.StringValue(files["cert"])
+	m.Chain = types.StringValue(files["chain"])
+	m.Fullchain = types.StringValue(files["fullchain"])
+
+	certificates, err := ParseCertificates(files["cert"])
+	if err != nil {
+		diags.AddError("Invalid Certificate", fmt.Sprintf("Unable to parse the downloaded certificate, got error: %s", err))
+		return
+	}
+	certificate := certificates[0]
+
+	fingerprint := sha256.Sum256(certificate.Raw)
+
+	m.Subject = types.StringValue(certificate.Subject.String())
+	m.Issuer = types.StringValue(certificate.Issuer.String())
+	m.SerialNumber = types.StringValue(certificate.SerialNumber.Text(16))
+	m.NotBefore = types.StringValue(certificate.NotBefore.UTC().Format(time.RFC3339))
+	m.NotAfter = types.StringValue(certificate.NotAfter.UTC().Format(time.RFC3339))
+	m.Sha256Fingerprint = types.StringValue(hex.EncodeToString(fingerprint[:]))
+	m.KeyAlgorithm = types.StringValue(certificate.PublicKeyAlgorithm.String())
+
+	m.SubjectAlternativeNames, tmpDiags = types.SetValueFrom(ctx, types.StringType, CertificateSubjectAlternativeNames(certificate))
+	diags.Append(tmpDiags...)
+}
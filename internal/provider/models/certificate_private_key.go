@@ -0,0 +1,11 @@
+// Copyright (c) Sander Jochems
+// SPDX-License-Identifier: MIT
+
+package models
+
+import "github.com/hashicorp/terraform-plugin-framework/types"
+
+type CertificatePrivateKey struct {
+	Id         types.Int64  `tfsdk:"id"`
+	PrivateKey types.String `tfsdk:"private_key"`
+}
@@ -0,0 +1,12 @@
+// Copyright (c) Sander Jochems
+// SPDX-License-Identifier: MIT
+
+package models
+
+import (
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+type CertificateRenewAction struct {
+	CertificateId types.Int64 `tfsdk:"certificate_id"`
+}
@@ -0,0 +1,12 @@
+// Copyright (c) Sander Jochems
+// SPDX-License-Identifier: MIT
+
+package models
+
+import (
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+type CertificateTestHttpAction struct {
+	DomainNames types.Set `tfsdk:"domain_names"`
+}
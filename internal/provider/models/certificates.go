@@ -5,22 +5,55 @@ package models
 
 import (
 	"context"
+	"fmt"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/sander0542/nginxproxymanager-go"
+	"regexp"
 )
 
 type Certificates struct {
+	ProviderName  types.String `tfsdk:"provider_name"`
+	Domain        types.String `tfsdk:"domain"`
+	ExpiresWithin types.Int64  `tfsdk:"expires_within"`
+	NiceNameRegex types.String `tfsdk:"nice_name_regex"`
+
 	Certificates types.Set `tfsdk:"certificates"`
 }
 
 func (m *Certificates) Write(ctx context.Context, certificates *[]nginxproxymanager.GetCertificates200ResponseInner, diags *diag.Diagnostics) {
 	var tmpDiags diag.Diagnostics
 
+	var niceNameRegex *regexp.Regexp
+	if !m.NiceNameRegex.IsNull() {
+		var err error
+		niceNameRegex, err = regexp.Compile(m.NiceNameRegex.ValueString())
+		if err != nil {
+			diags.AddAttributeError(path.Root("nice_name_regex"), "Invalid Regular Expression", fmt.Sprintf("Unable to compile regular expression, got error: %s", err))
+			return
+		}
+	}
+
 	elements := make([]Certificate, 0, len(*certificates))
 	for _, g := range *certificates {
+		if !m.ProviderName.IsNull() && g.GetProvider() != m.ProviderName.ValueString() {
+			continue
+		}
+		if !m.Domain.IsNull() && !DomainCovered(g.GetDomainNames(), m.Domain.ValueString()) {
+			continue
+		}
+		if niceNameRegex != nil && !niceNameRegex.MatchString(g.GetNiceName()) {
+			continue
+		}
+
 		item := Certificate{}
 		item.Write(ctx, &g, diags)
+
+		if !m.ExpiresWithin.IsNull() && (item.DaysUntilExpiry.IsNull() || item.DaysUntilExpiry.ValueInt64() >= m.ExpiresWithin.ValueInt64()) {
+			continue
+		}
+
 		elements = append(elements, item)
 	}
 
@@ -0,0 +1,26 @@
+// Copyright (c) Sander Jochems
+// SPDX-License-Identifier: MIT
+
+package models
+
+import (
+	"context"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/sander0542/nginxproxymanager-go"
+)
+
+type DeadHostResource struct {
+	DeadHost
+
+	CertificateSelection types.String `tfsdk:"certificate_selection"`
+}
+
+func (m *DeadHostResource) Write(ctx context.Context, deadHost *nginxproxymanager.GetDeadHosts200ResponseInner, diags *diag.Diagnostics) {
+	m.DeadHost.Write(ctx, deadHost, diags)
+
+	// The selection is not stored by Nginx Proxy Manager, so imported dead hosts are managed manually.
+	if m.CertificateSelection.IsNull() {
+		m.CertificateSelection = types.StringValue("manual")
+	}
+}
@@ -0,0 +1,48 @@
+// Copyright (c) Sander Jochems
+// SPDX-License-Identifier: MIT
+
+package models
+
+import (
+	"bufio"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// RenderDnsCredentials renders the credentials to the INI format used by the certbot DNS plugins.
+func RenderDnsCredentials(credentials map[string]string) string {
+	keys := make([]string, 0, len(credentials))
+	for key := range credentials {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var builder strings.Builder
+	for _, key := range keys {
+		builder.WriteString(fmt.Sprintf("%s = %s\n", key, credentials[key]))
+	}
+
+	return builder.String()
+}
+
+// ParseDnsCredentials parses the keys of credentials in the INI format used by the certbot DNS plugins.
+func ParseDnsCredentials(content string) map[string]string {
+	credentials := map[string]string{}
+
+	scanner := bufio.NewScanner(strings.NewReader(content))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, ";") {
+			continue
+		}
+
+		key, value, ok := strings.Cut(line, "=")
+		if !ok {
+			continue
+		}
+		credentials[strings.TrimSpace(key)] = strings.TrimSpace(value)
+	}
+
+	return credentials
+}
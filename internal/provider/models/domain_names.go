@@ -8,8 +8,13 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"golang.org/x/net/idna"
+	"strings"
 )
 
+// domainNameProfile converts domain names the way they are looked up, but allows labels such as _acme-challenge.
+var domainNameProfile = idna.New(idna.MapForLookup(), idna.StrictDomainName(false), idna.VerifyDNSLength(true))
+
 func SetDomainNamesFrom(ctx context.Context, domainNames []string) (basetypes.SetValue, diag.Diagnostics) {
 	return types.SetValueFrom(ctx, types.StringType, domainNames)
 }
@@ -20,3 +25,74 @@ func DomainNameElementsAs(ctx context.Context, set types.Set) ([]string, diag.Di
 
 	return domainNames, diags
 }
+
+// NormalizeDomain returns the domain name in lowercase, without a trailing dot and with internationalized labels
+// converted to punycode. A leading wildcard label is kept.
+func NormalizeDomain(domainName string) (string, error) {
+	domainName = strings.TrimSuffix(domainName, ".")
+
+	prefix := ""
+	if rest, ok := strings.CutPrefix(domainName, "*."); ok {
+		prefix, domainName = "*.", rest
+	}
+
+	ascii, err := domainNameProfile.ToASCII(domainName)
+	if err != nil {
+		return "", err
+	}
+
+	return prefix + ascii, nil
+}
+
+// normalizeDomainOrLower normalizes the domain name, falling back to lowercase when it is not a valid domain name.
+func normalizeDomainOrLower(domainName string) string {
+	if normalized, err := NormalizeDomain(domainName); err == nil {
+		return normalized
+	}
+
+	return strings.TrimSuffix(strings.ToLower(domainName), ".")
+}
+
+// DomainMatches returns whether the domain name is matched by the pattern, ignoring case and comparing
+// internationalized domain names by their punycode. A wildcard label in the pattern matches exactly one label of the
+// domain name, so *.example.com matches app.example.com, but neither example.com nor a.b.example.com. A wildcard
+// domain name is only matched by the same wildcard pattern.
+func DomainMatches(pattern string, domainName string) bool {
+	pattern = normalizeDomainOrLower(pattern)
+	domainName = normalizeDomainOrLower(domainName)
+
+	if pattern == domainName {
+		return true
+	}
+
+	suffix, ok := strings.CutPrefix(pattern, "*.")
+	if !ok {
+		return false
+	}
+
+	label, rest, ok := strings.Cut(domainName, ".")
+
+	return ok && label != "" && label != "*" && rest == suffix
+}
+
+// DomainCovered returns whether the domain name is matched by any of the domain names of a certificate.
+func DomainCovered(certificateDomainNames []string, domainName string) bool {
+	for _, pattern := range certificateDomainNames {
+		if DomainMatches(pattern, domainName) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// DomainsCovered returns whether every domain name is matched by any of the domain names of a certificate.
+func DomainsCovered(certificateDomainNames []string, domainNames []string) bool {
+	for _, domainName := range domainNames {
+		if !DomainCovered(certificateDomainNames, domainName) {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,236 @@
+// Copyright (c) Sander Jochems
+// SPDX-License-Identifier: MIT
+
+package models
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestNormalizeDomain(t *testing.T) {
+	tests := map[string]struct {
+		domainName string
+		expected   string
+		err        bool
+	}{
+		"lowercase": {
+			domainName: "example.com",
+			expected:   "example.com",
+		},
+		"mixed-case": {
+			domainName: "App.EXAMPLE.com",
+			expected:   "app.example.com",
+		},
+		"trailing-dot": {
+			domainName: "example.com.",
+			expected:   "example.com",
+		},
+		"internationalized": {
+			domainName: "Bücher.example.com",
+			expected:   "xn--bcher-kva.example.com",
+		},
+		"punycode": {
+			domainName: "xn--bcher-kva.example.com",
+			expected:   "xn--bcher-kva.example.com",
+		},
+		"underscore": {
+			domainName: "_acme-challenge.Example.com",
+			expected:   "_acme-challenge.example.com",
+		},
+		"wildcard": {
+			domainName: "*.Example.com.",
+			expected:   "*.example.com",
+		},
+		"wildcard-internationalized": {
+			domainName: "*.bücher.example.com",
+			expected:   "*.xn--bcher-kva.example.com",
+		},
+		"empty-label": {
+			domainName: "app..example.com",
+			err:        true,
+		},
+		"long-label": {
+			domainName: strings.Repeat("a", 64) + ".example.com",
+			err:        true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			actual, err := NormalizeDomain(test.domainName)
+			if test.err {
+				if err == nil {
+					t.Fatalf("expected error, got %q", actual)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if actual != test.expected {
+				t.Errorf("expected %q, got %q", test.expected, actual)
+			}
+		})
+	}
+}
+
+func TestDomainMatches(t *testing.T) {
+	tests := map[string]struct {
+		pattern    string
+		domainName string
+		expected   bool
+	}{
+		"exact": {
+			pattern:    "example.com",
+			domainName: "example.com",
+			expected:   true,
+		},
+		"exact-other": {
+			pattern:    "example.com",
+			domainName: "app.example.com",
+			expected:   false,
+		},
+		"wildcard-apex": {
+			pattern:    "*.example.com",
+			domainName: "example.com",
+			expected:   false,
+		},
+		"wildcard-single-label": {
+			pattern:    "*.example.com",
+			domainName: "a.example.com",
+			expected:   true,
+		},
+		"wildcard-several-labels": {
+			pattern:    "*.example.com",
+			domainName: "a.b.example.com",
+			expected:   false,
+		},
+		"wildcard-other-domain": {
+			pattern:    "*.example.com",
+			domainName: "a.example.org",
+			expected:   false,
+		},
+		"wildcard-same-wildcard": {
+			pattern:    "*.example.com",
+			domainName: "*.example.com",
+			expected:   true,
+		},
+		"wildcard-nested-wildcard": {
+			pattern:    "*.example.com",
+			domainName: "*.a.example.com",
+			expected:   false,
+		},
+		"wildcard-parent-wildcard": {
+			pattern:    "*.a.example.com",
+			domainName: "*.example.com",
+			expected:   false,
+		},
+		"exact-wildcard": {
+			pattern:    "a.example.com",
+			domainName: "*.example.com",
+			expected:   false,
+		},
+		"trailing-dot": {
+			pattern:    "example.com.",
+			domainName: "example.com",
+			expected:   true,
+		},
+		"wildcard-trailing-dot": {
+			pattern:    "*.example.com",
+			domainName: "a.example.com.",
+			expected:   true,
+		},
+		"mixed-case": {
+			pattern:    "*.EXAMPLE.com",
+			domainName: "App.Example.COM",
+			expected:   true,
+		},
+		"internationalized": {
+			pattern:    "xn--bcher-kva.example.com",
+			domainName: "Bücher.example.com",
+			expected:   true,
+		},
+		"wildcard-internationalized": {
+			pattern:    "*.example.com",
+			domainName: "Bücher.example.com",
+			expected:   true,
+		},
+		"underscore": {
+			pattern:    "*.example.com",
+			domainName: "_acme-challenge.example.com",
+			expected:   true,
+		},
+		"underscore-exact": {
+			pattern:    "_acme-challenge.example.com",
+			domainName: "_ACME-challenge.example.com",
+			expected:   true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			if actual := DomainMatches(test.pattern, test.domainName); actual != test.expected {
+				t.Errorf("expected %t, got %t", test.expected, actual)
+			}
+		})
+	}
+}
+
+func TestDomainsCovered(t *testing.T) {
+	tests := map[string]struct {
+		certificateDomainNames []string
+		domainNames            []string
+		expected               bool
+	}{
+		"no-domain-names": {
+			certificateDomainNames: []string{"example.com"},
+			domainNames:            []string{},
+			expected:               true,
+		},
+		"no-certificate-domain-names": {
+			certificateDomainNames: []string{},
+			domainNames:            []string{"example.com"},
+			expected:               false,
+		},
+		"apex-and-wildcard": {
+			certificateDomainNames: []string{"example.com", "*.example.com"},
+			domainNames:            []string{"example.com", "www.example.com", "Bücher.example.com"},
+			expected:               true,
+		},
+		"wildcard-without-apex": {
+			certificateDomainNames: []string{"*.example.com"},
+			domainNames:            []string{"example.com", "www.example.com"},
+			expected:               false,
+		},
+		"wildcard-too-deep": {
+			certificateDomainNames: []string{"example.com", "*.example.com"},
+			domainNames:            []string{"a.b.example.com"},
+			expected:               false,
+		},
+		"wildcard-host": {
+			certificateDomainNames: []string{"*.example.com"},
+			domainNames:            []string{"*.example.com"},
+			expected:               true,
+		},
+		"wildcard-host-exact-certificate": {
+			certificateDomainNames: []string{"a.example.com", "b.example.com"},
+			domainNames:            []string{"*.example.com"},
+			expected:               false,
+		},
+		"normalized": {
+			certificateDomainNames: []string{"EXAMPLE.com.", "xn--bcher-kva.example.com"},
+			domainNames:            []string{"example.COM", "bücher.example.com."},
+			expected:               true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			if actual := DomainsCovered(test.certificateDomainNames, test.domainNames); actual != test.expected {
+				t.Errorf("expected %t, got %t", test.expected, actual)
+			}
+		})
+	}
+}
@@ -0,0 +1,20 @@
+// Copyright (c) Sander Jochems
+// SPDX-License-Identifier: MIT
+
+package models
+
+import (
+	"fmt"
+	"strings"
+)
+
+// HostReference is a host that references a certificate or an access list.
+type HostReference struct {
+	Type string
+	Id   int64
+	Name string
+}
+
+func (h HostReference) String() string {
+	return fmt.Sprintf("%s %d (%s)", strings.ReplaceAll(h.Type, "_", " "), h.Id, h.Name)
+}
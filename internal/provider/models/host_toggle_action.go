@@ -0,0 +1,14 @@
+// Copyright (c) Sander Jochems
+// SPDX-License-Identifier: MIT
+
+package models
+
+import (
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+type HostToggleAction struct {
+	HostType types.String `tfsdk:"host_type"`
+	HostId   types.Int64  `tfsdk:"host_id"`
+	Enabled  types.Bool   `tfsdk:"enabled"`
+}
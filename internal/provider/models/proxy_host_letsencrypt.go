@@ -0,0 +1,70 @@
+// Copyright (c) Sander Jochems
+// SPDX-License-Identifier: MIT
+
+package models
+
+import (
+	"context"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/sander0542/nginxproxymanager-go"
+)
+
+type ProxyHostLetsencrypt struct {
+	Email              types.String `tfsdk:"email"`
+	Agree              types.Bool   `tfsdk:"agree"`
+	DnsChallenge       types.Bool   `tfsdk:"dns_challenge"`
+	DnsProvider        types.String `tfsdk:"dns_provider"`
+	DnsCredentials     types.Map    `tfsdk:"dns_credentials"`
+	PropagationSeconds types.Int64  `tfsdk:"propagation_seconds"`
+	PreflightHttpCheck types.Bool   `tfsdk:"preflight_http_check"`
+}
+
+func (ProxyHostLetsencrypt) GetType() attr.Type {
+	return types.ObjectType{}.WithAttributeTypes(map[string]attr.Type{
+		"email":                types.StringType,
+		"agree":                types.BoolType,
+		"dns_challenge":        types.BoolType,
+		"dns_provider":         types.StringType,
+		"dns_credentials":      types.MapType{ElemType: types.StringType},
+		"propagation_seconds":  types.Int64Type,
+		"preflight_http_check": types.BoolType,
+	})
+}
+
+// ToCreateRequest returns the request to create a Let's Encrypt certificate for the domain names of the proxy host.
+func (m *ProxyHostLetsencrypt) ToCreateRequest(ctx context.Context, domainNames types.Set, diags *diag.Diagnostics) *nginxproxymanager.CreateCertificateRequest {
+	certificate := CertificateLetsencrypt{
+		DomainNames:              domainNames,
+		LetsencryptEmail:         m.Email,
+		LetsencryptAgree:         m.Agree,
+		DnsChallenge:             m.DnsChallenge,
+		DnsProvider:              m.DnsProvider,
+		DnsProviderCredentials:   types.StringNull(),
+		DnsProviderCredentialsWo: types.StringNull(),
+		DnsCredentials:           m.DnsCredentials,
+		PropagationSeconds:       m.PropagationSeconds,
+	}
+
+	return certificate.ToCreateRequest(ctx, diags)
+}
+
+// RequestEquals returns whether both settings request the same certificate, ignoring the preflight HTTP check which only
+// affects how the certificate is requested.
+func (m *ProxyHostLetsencrypt) RequestEquals(other *ProxyHostLetsencrypt) bool {
+	return m.Email.Equal(other.Email) &&
+		m.Agree.Equal(other.Agree) &&
+		m.DnsChallenge.Equal(other.DnsChallenge) &&
+		m.DnsProvider.Equal(other.DnsProvider) &&
+		m.DnsCredentials.Equal(other.DnsCredentials) &&
+		m.PropagationSeconds.Equal(other.PropagationSeconds)
+}
+
+func ProxyHostLetsencryptAs(ctx context.Context, object types.Object) (*ProxyHostLetsencrypt, diag.Diagnostics) {
+	var letsencrypt *ProxyHostLetsencrypt
+	diags := object.As(ctx, &letsencrypt, basetypes.ObjectAsOptions{})
+
+	return letsencrypt, diags
+}
@@ -0,0 +1,30 @@
+// Copyright (c) Sander Jochems
+// SPDX-License-Identifier: MIT
+
+package models
+
+import (
+	"context"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/sander0542/nginxproxymanager-go"
+)
+
+type ProxyHostResource struct {
+	ProxyHost
+
+	CertificateSelection types.String `tfsdk:"certificate_selection"`
+	Letsencrypt          types.Object `tfsdk:"letsencrypt"`
+
+	Timeouts timeouts.Value `tfsdk:"timeouts"`
+}
+
+func (m *ProxyHostResource) Write(ctx context.Context, proxyHost *nginxproxymanager.GetProxyHosts200ResponseInner, diags *diag.Diagnostics) {
+	m.ProxyHost.Write(ctx, proxyHost, diags)
+
+	// The selection is not stored by Nginx Proxy Manager, so imported proxy hosts are managed manually.
+	if m.CertificateSelection.IsNull() {
+		m.CertificateSelection = types.StringValue("manual")
+	}
+}
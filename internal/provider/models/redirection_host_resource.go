@@ -0,0 +1,26 @@
+// Copyright (c) Sander Jochems
+// SPDX-License-Identifier: MIT
+
+package models
+
+import (
+	"context"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/sander0542/nginxproxymanager-go"
+)
+
+type RedirectionHostResource struct {
+	RedirectionHost
+
+	CertificateSelection types.String `tfsdk:"certificate_selection"`
+}
+
+func (m *RedirectionHostResource) Write(ctx context.Context, redirectionHost *nginxproxymanager.GetRedirectionHosts200ResponseInner, diags *diag.Diagnostics) {
+	m.RedirectionHost.Write(ctx, redirectionHost, diags)
+
+	// The selection is not stored by Nginx Proxy Manager, so imported redirection hosts are managed manually.
+	if m.CertificateSelection.IsNull() {
+		m.CertificateSelection = types.StringValue("manual")
+	}
+}
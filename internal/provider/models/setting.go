@@ -0,0 +1,100 @@
+// Copyright (c) Sander Jochems
+// SPDX-License-Identifier: MIT
+
+package models
+
+import (
+	"context"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/sander0542/nginxproxymanager-go"
+)
+
+// TypedSettingIds contains the settings that have a dedicated attribute and are therefore not part of the generic settings map.
+var TypedSettingIds = map[string]bool{
+	"default-site": true,
+}
+
+type Setting struct {
+	Value types.String `tfsdk:"value"`
+	Meta  types.Map    `tfsdk:"meta"`
+}
+
+func (Setting) GetType() attr.TypeWithAttributeTypes {
+	return types.ObjectType{}.WithAttributeTypes(map[string]attr.Type{
+		"value": types.StringType,
+		"meta":  types.MapType{ElemType: types.StringType},
+	})
+}
+
+func (m *Setting) Write(ctx context.Context, setting *nginxproxymanager.GetSettings200ResponseInner, diags *diag.Diagnostics) {
+	var tmpDiags diag.Diagnostics
+
+	m.Value = types.StringPointerValue(setting.GetValue().String)
+	m.Meta, tmpDiags = MapMetaFrom(ctx, setting.GetMeta())
+	diags.Append(tmpDiags...)
+}
+
+// SettingRequest is the body of a setting update. The SDK only knows the meta keys of the default site, so settings
+// without a dedicated attribute are updated with this body, which passes every meta key through.
+type SettingRequest struct {
+	Value *string                `json:"value"`
+	Meta  map[string]interface{} `json:"meta,omitempty"`
+}
+
+func (m *Setting) ToRequest() *SettingRequest {
+	request := &SettingRequest{
+		Value: m.Value.ValueStringPointer(),
+	}
+
+	if m.Meta.IsNull() || m.Meta.IsUnknown() {
+		return request
+	}
+
+	request.Meta = map[string]interface{}{}
+	for key, value := range m.Meta.Elements() {
+		stringValue, ok := value.(types.String)
+		if !ok || stringValue.IsUnknown() {
+			continue
+		}
+
+		request.Meta[key] = stringValue.ValueStringPointer()
+	}
+
+	return request
+}
+
+// MapSettingsFrom creates a map of all settings without a dedicated attribute. When include is not nil, only the settings
+// with an Id in include are added to the map.
+func MapSettingsFrom(ctx context.Context, settings []nginxproxymanager.GetSettings200ResponseInner, include map[string]attr.Value) (types.Map, diag.Diagnostics) {
+	diags := diag.Diagnostics{}
+
+	elements := map[string]Setting{}
+	for _, setting := range settings {
+		if TypedSettingIds[setting.GetId()] {
+			continue
+		}
+		if include != nil {
+			if _, ok := include[setting.GetId()]; !ok {
+				continue
+			}
+		}
+
+		element := Setting{}
+		element.Write(ctx, &setting, &diags)
+		elements[setting.GetId()] = element
+	}
+
+	settingsMap, mapDiags := types.MapValueFrom(ctx, Setting{}.GetType(), elements)
+	diags.Append(mapDiags...)
+
+	return settingsMap, diags
+}
+
+func SettingsElementsAs(ctx context.Context, settingsMap types.Map) (map[string]Setting, diag.Diagnostics) {
+	settings := map[string]Setting{}
+	diags := settingsMap.ElementsAs(ctx, &settings, false)
+
+	return settings, diags
+}
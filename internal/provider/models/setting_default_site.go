@@ -5,53 +5,64 @@ package models
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/types"
-	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
 	"github.com/sander0542/nginxproxymanager-go"
 )
 
 type SettingDefaultSite struct {
-	Page     types.String `tfsdk:"page"`
-	Redirect types.String `tfsdk:"redirect"`
-	Html     types.String `tfsdk:"html"`
+	Page       types.String `tfsdk:"page"`
+	Redirect   types.String `tfsdk:"redirect"`
+	Html       types.String `tfsdk:"html"`
+	HtmlSha256 types.String `tfsdk:"html_sha256"`
 }
 
 func (SettingDefaultSite) GetType() attr.TypeWithAttributeTypes {
 	return types.ObjectType{}.WithAttributeTypes(map[string]attr.Type{
-		"page":     types.StringType,
-		"redirect": types.StringType,
-		"html":     types.StringType,
+		"page":        types.StringType,
+		"redirect":    types.StringType,
+		"html":        types.StringType,
+		"html_sha256": types.StringType,
 	})
 }
 
 func (m *SettingDefaultSite) Write(ctx context.Context, setting *nginxproxymanager.GetSettings200ResponseInner, diags *diag.Diagnostics) {
 	m.Page = types.StringPointerValue(setting.GetValue().String)
+	m.Redirect = settingMetaString(setting.GetMeta(), "redirect")
+	m.Html = settingMetaString(setting.GetMeta(), "html")
+	m.HtmlSha256 = HtmlSha256(m.Html)
+}
 
-	if redirect, ok := setting.GetMeta()["redirect"]; ok {
-		m.Redirect = types.StringValue(fmt.Sprintf("%v", redirect))
-	} else {
-		m.Redirect = types.StringNull()
+// HtmlSha256 returns the hex encoded SHA-256 checksum of the HTML content, or null when there is no content.
+func HtmlSha256(html types.String) types.String {
+	if html.IsUnknown() {
+		return types.StringUnknown()
 	}
-	if html, ok := setting.GetMeta()["html"]; ok {
-		m.Html = types.StringValue(fmt.Sprintf("%v", html))
-	} else {
-		m.Html = types.StringNull()
+	if html.ValueString() == "" {
+		return types.StringNull()
 	}
+
+	checksum := sha256.Sum256([]byte(html.ValueString()))
+	return types.StringValue(hex.EncodeToString(checksum[:]))
 }
 
-func (m *SettingDefaultSite) ToRequest(ctx context.Context, diags *diag.Diagnostics) *nginxproxymanager.UpdateSettingRequest {
-	meta := nginxproxymanager.UpdateSettingRequestMeta{}
-	meta.SetRedirect(m.Redirect.ValueString())
-	meta.SetHtml(m.Html.ValueString())
+// settingMetaString returns the meta value as a string, treating empty values as not set.
+func settingMetaString(meta map[string]interface{}, key string) types.String {
+	value, ok := meta[key]
+	if !ok || value == nil {
+		return types.StringNull()
+	}
 
-	request := nginxproxymanager.NewUpdateSettingRequest()
-	request.SetValue(m.Page.ValueString())
-	request.SetMeta(meta)
+	stringValue := fmt.Sprintf("%v", value)
+	if stringValue == "" {
+		return types.StringNull()
+	}
 
-	return request
+	return types.StringValue(stringValue)
 }
 
 func ObjectSettingDefaultSiteFrom(ctx context.Context, setting nginxproxymanager.GetSettings200ResponseInner) (types.Object, diag.Diagnostics) {
@@ -65,10 +76,3 @@ func ObjectSettingDefaultSiteFrom(ctx context.Context, setting nginxproxymanager
 
 	return object, diags
 }
-
-func SettingDefaultSiteAs(ctx context.Context, object types.Object) (SettingDefaultSite, diag.Diagnostics) {
-	setting := SettingDefaultSite{}
-	diags := object.As(ctx, &setting, basetypes.ObjectAsOptions{})
-
-	return setting, diags
-}
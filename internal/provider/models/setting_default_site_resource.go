@@ -0,0 +1,107 @@
+// Copyright (c) Sander Jochems
+// SPDX-License-Identifier: MIT
+
+package models
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/sander0542/nginxproxymanager-go"
+	"os"
+)
+
+type SettingDefaultSiteResource struct {
+	Page       types.String `tfsdk:"page"`
+	Redirect   types.String `tfsdk:"redirect"`
+	Html       types.String `tfsdk:"html"`
+	HtmlFile   types.String `tfsdk:"html_file"`
+	HtmlSha256 types.String `tfsdk:"html_sha256"`
+}
+
+func (SettingDefaultSiteResource) GetType() attr.TypeWithAttributeTypes {
+	return types.ObjectType{}.WithAttributeTypes(map[string]attr.Type{
+		"page":        types.StringType,
+		"redirect":    types.StringType,
+		"html":        types.StringType,
+		"html_file":   types.StringType,
+		"html_sha256": types.StringType,
+	})
+}
+
+func (m *SettingDefaultSiteResource) Write(ctx context.Context, setting *nginxproxymanager.GetSettings200ResponseInner, diags *diag.Diagnostics) {
+	html := settingMetaString(setting.GetMeta(), "html")
+
+	m.Page = types.StringPointerValue(setting.GetValue().String)
+	m.Redirect = settingMetaString(setting.GetMeta(), "redirect")
+	m.HtmlSha256 = HtmlSha256(html)
+
+	// When the content is loaded from a file, only the checksum is stored to keep the state small.
+	if m.HtmlFile.IsNull() {
+		m.Html = html
+	} else {
+		m.Html = types.StringNull()
+	}
+}
+
+// GetHtml returns the configured HTML content, reading it from html_file when set.
+func (m *SettingDefaultSiteResource) GetHtml(attributePath path.Path, diags *diag.Diagnostics) types.String {
+	if m.HtmlFile.IsUnknown() {
+		return types.StringUnknown()
+	}
+	if m.HtmlFile.IsNull() {
+		return m.Html
+	}
+
+	content, err := os.ReadFile(m.HtmlFile.ValueString())
+	if err != nil {
+		diags.AddAttributeError(attributePath.AtName("html_file"), "File Error", fmt.Sprintf("Unable to read HTML file, got error: %s", err))
+		return types.StringUnknown()
+	}
+
+	return types.StringValue(string(content))
+}
+
+func (m *SettingDefaultSiteResource) ToRequest(ctx context.Context, attributePath path.Path, diags *diag.Diagnostics) *nginxproxymanager.UpdateSettingRequest {
+	meta := nginxproxymanager.UpdateSettingRequestMeta{}
+	meta.SetRedirect(m.Redirect.ValueString())
+	meta.SetHtml(m.GetHtml(attributePath, diags).ValueString())
+
+	request := nginxproxymanager.NewUpdateSettingRequest()
+	request.SetValue(m.Page.ValueString())
+	request.SetMeta(meta)
+
+	return request
+}
+
+// ObjectSettingDefaultSiteResourceFrom creates the object for the setting, keeping html_file from the current object.
+func ObjectSettingDefaultSiteResourceFrom(ctx context.Context, setting nginxproxymanager.GetSettings200ResponseInner, current types.Object) (types.Object, diag.Diagnostics) {
+	diags := diag.Diagnostics{}
+
+	attributes := SettingDefaultSiteResource{
+		HtmlFile: types.StringNull(),
+	}
+	if !current.IsNull() && !current.IsUnknown() {
+		currentAttributes, currentDiags := SettingDefaultSiteResourceAs(ctx, current)
+		diags.Append(currentDiags...)
+
+		attributes.HtmlFile = currentAttributes.HtmlFile
+	}
+	attributes.Write(ctx, &setting, &diags)
+
+	object, objectDiags := types.ObjectValueFrom(ctx, SettingDefaultSiteResource{}.GetType().AttributeTypes(), attributes)
+	diags.Append(objectDiags...)
+
+	return object, diags
+}
+
+func SettingDefaultSiteResourceAs(ctx context.Context, object types.Object) (SettingDefaultSiteResource, diag.Diagnostics) {
+	setting := SettingDefaultSiteResource{}
+	diags := object.As(ctx, &setting, basetypes.ObjectAsOptions{})
+
+	return setting, diags
+}
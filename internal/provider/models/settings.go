@@ -12,6 +12,7 @@ import (
 
 type Settings struct {
 	DefaultSite types.Object `tfsdk:"default_site"`
+	Settings    types.Map    `tfsdk:"settings"`
 }
 
 func (m *Settings) Write(ctx context.Context, settings []nginxproxymanager.GetSettings200ResponseInner, diags *diag.Diagnostics) {
@@ -25,4 +26,8 @@ func (m *Settings) Write(ctx context.Context, settings []nginxproxymanager.GetSe
 
 		diags.Append(tmpDiags...)
 	}
+
+	var tmpDiags diag.Diagnostics
+	m.Settings, tmpDiags = MapSettingsFrom(ctx, settings, nil)
+	diags.Append(tmpDiags...)
 }
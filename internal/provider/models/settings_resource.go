@@ -0,0 +1,45 @@
+// Copyright (c) Sander Jochems
+// SPDX-License-Identifier: MIT
+
+package models
+
+import (
+	"context"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/sander0542/nginxproxymanager-go"
+)
+
+type SettingsResource struct {
+	DefaultSite    types.Object `tfsdk:"default_site"`
+	Settings       types.Map    `tfsdk:"settings"`
+	ResetOnDestroy types.Bool   `tfsdk:"reset_on_destroy"`
+}
+
+func (m *SettingsResource) Write(ctx context.Context, settings []nginxproxymanager.GetSettings200ResponseInner, diags *diag.Diagnostics) {
+	for _, setting := range settings {
+		var tmpDiags diag.Diagnostics
+
+		switch setting.GetId() {
+		case "default-site":
+			m.DefaultSite, tmpDiags = ObjectSettingDefaultSiteResourceFrom(ctx, setting, m.DefaultSite)
+		}
+
+		diags.Append(tmpDiags...)
+	}
+
+	// Only keep track of the generic settings that are already managed, so settings that are not configured do not cause a diff.
+	var include map[string]attr.Value
+	if !m.Settings.IsNull() && !m.Settings.IsUnknown() {
+		include = m.Settings.Elements()
+	}
+
+	var tmpDiags diag.Diagnostics
+	m.Settings, tmpDiags = MapSettingsFrom(ctx, settings, include)
+	diags.Append(tmpDiags...)
+
+	if m.ResetOnDestroy.IsNull() || m.ResetOnDestroy.IsUnknown() {
+		m.ResetOnDestroy = types.BoolValue(false)
+	}
+}
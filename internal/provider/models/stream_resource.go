@@ -0,0 +1,27 @@
+// Copyright (c) Sander Jochems
+// SPDX-License-Identifier: MIT
+
+package models
+
+import (
+	"context"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/sander0542/nginxproxymanager-go"
+)
+
+type StreamResource struct {
+	Stream
+
+	CertificateDomainNames types.Set    `tfsdk:"certificate_domain_names"`
+	CertificateSelection   types.String `tfsdk:"certificate_selection"`
+}
+
+func (m *StreamResource) Write(ctx context.Context, stream *nginxproxymanager.GetStreams200ResponseInner, diags *diag.Diagnostics) {
+	m.Stream.Write(ctx, stream, diags)
+
+	// The selection is not stored by Nginx Proxy Manager, so imported streams are managed manually.
+	if m.CertificateSelection.IsNull() {
+		m.CertificateSelection = types.StringValue("manual")
+	}
+}
@@ -0,0 +1,40 @@
+// Copyright (c) Sander Jochems
+// SPDX-License-Identifier: MIT
+
+package models
+
+import (
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"math"
+	"time"
+)
+
+// timeLayouts contains the layouts Nginx Proxy Manager uses for dates, depending on the version and database.
+var timeLayouts = []string{
+	time.RFC3339,
+	"2006-01-02 15:04:05",
+	"2006-01-02T15:04:05",
+}
+
+// ParseTime parses a date and time returned by Nginx Proxy Manager.
+func ParseTime(value string) (time.Time, error) {
+	for _, layout := range timeLayouts {
+		if t, err := time.Parse(layout, value); err == nil {
+			return t, nil
+		}
+	}
+
+	return time.Time{}, fmt.Errorf("unsupported date format: %q", value)
+}
+
+// DaysUntil returns the number of whole days until the date and time returned by Nginx Proxy Manager, which is
+// negative once it has passed, or null when it cannot be parsed.
+func DaysUntil(value string) types.Int64 {
+	t, err := ParseTime(value)
+	if err != nil {
+		return types.Int64Null()
+	}
+
+	return types.Int64Value(int64(math.Floor(time.Until(t).Hours() / 24)))
+}
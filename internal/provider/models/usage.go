@@ -0,0 +1,92 @@
+// Copyright (c) Sander Jochems
+// SPDX-License-Identifier: MIT
+
+package models
+
+import (
+	"context"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"strconv"
+)
+
+type Usage struct {
+	UnusedCertificateIds       types.Set `tfsdk:"unused_certificate_ids"`
+	UnusedAccessListIds        types.Set `tfsdk:"unused_access_list_ids"`
+	MissingReferences          types.Set `tfsdk:"missing_references"`
+	CertificateReferenceCounts types.Map `tfsdk:"certificate_reference_counts"`
+	AccessListReferenceCounts  types.Map `tfsdk:"access_list_reference_counts"`
+}
+
+type UsageMissingReference struct {
+	HostType   types.String `tfsdk:"host_type"`
+	HostId     types.Int64  `tfsdk:"host_id"`
+	HostName   types.String `tfsdk:"host_name"`
+	ObjectType types.String `tfsdk:"object_type"`
+	ObjectId   types.Int64  `tfsdk:"object_id"`
+}
+
+func (UsageMissingReference) GetType() attr.Type {
+	return types.ObjectType{}.WithAttributeTypes(map[string]attr.Type{
+		"host_type":   types.StringType,
+		"host_id":     types.Int64Type,
+		"host_name":   types.StringType,
+		"object_type": types.StringType,
+		"object_id":   types.Int64Type,
+	})
+}
+
+// Write cross-references the existing certificates and access lists with the hosts that reference them by their Id.
+func (m *Usage) Write(ctx context.Context, certificateIds []int64, accessListIds []int64, certificateHosts map[int64][]HostReference, accessListHosts map[int64][]HostReference, diags *diag.Diagnostics) {
+	var tmpDiags diag.Diagnostics
+
+	missingReferences := []UsageMissingReference{}
+	unusedCertificateIds, certificateReferenceCounts, missingReferences := usageOf("certificate", certificateIds, certificateHosts, missingReferences)
+	unusedAccessListIds, accessListReferenceCounts, missingReferences := usageOf("access_list", accessListIds, accessListHosts, missingReferences)
+
+	m.UnusedCertificateIds, tmpDiags = types.SetValueFrom(ctx, types.Int64Type, unusedCertificateIds)
+	diags.Append(tmpDiags...)
+	m.UnusedAccessListIds, tmpDiags = types.SetValueFrom(ctx, types.Int64Type, unusedAccessListIds)
+	diags.Append(tmpDiags...)
+	m.CertificateReferenceCounts, tmpDiags = types.MapValueFrom(ctx, types.Int64Type, certificateReferenceCounts)
+	diags.Append(tmpDiags...)
+	m.AccessListReferenceCounts, tmpDiags = types.MapValueFrom(ctx, types.Int64Type, accessListReferenceCounts)
+	diags.Append(tmpDiags...)
+	m.MissingReferences, tmpDiags = types.SetValueFrom(ctx, UsageMissingReference{}.GetType(), missingReferences)
+	diags.Append(tmpDiags...)
+}
+
+// usageOf returns the unused objects and the reference count per object, and adds the references to objects that do
+// not exist to the missing references.
+func usageOf(objectType string, ids []int64, hosts map[int64][]HostReference, missingReferences []UsageMissingReference) ([]int64, map[string]int64, []UsageMissingReference) {
+	unused := []int64{}
+	counts := make(map[string]int64, len(ids))
+	exists := make(map[int64]bool, len(ids))
+
+	for _, id := range ids {
+		exists[id] = true
+		counts[strconv.FormatInt(id, 10)] = int64(len(hosts[id]))
+		if len(hosts[id]) == 0 {
+			unused = append(unused, id)
+		}
+	}
+
+	for id, references := range hosts {
+		if exists[id] {
+			continue
+		}
+
+		for _, host := range references {
+			missingReferences = append(missingReferences, UsageMissingReference{
+				HostType:   types.StringValue(host.Type),
+				HostId:     types.Int64Value(host.Id),
+				HostName:   types.StringValue(host.Name),
+				ObjectType: types.StringValue(objectType),
+				ObjectId:   types.Int64Value(id),
+			})
+		}
+	}
+
+	return unused, counts, missingReferences
+}
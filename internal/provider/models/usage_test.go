@@ -0,0 +1,82 @@
+// Copyright (c) Sander Jochems
+// SPDX-License-Identifier: MIT
+
+package models
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestUsageOf(t *testing.T) {
+	proxyHost := HostReference{Type: "proxy_host", Id: 1, Name: "example.com"}
+	stream := HostReference{Type: "stream", Id: 2, Name: "port 8443"}
+
+	tests := map[string]struct {
+		ids               []int64
+		hosts             map[int64][]HostReference
+		unused            []int64
+		counts            map[string]int64
+		missingReferences []UsageMissingReference
+	}{
+		"empty": {
+			ids:               []int64{},
+			hosts:             map[int64][]HostReference{},
+			unused:            []int64{},
+			counts:            map[string]int64{},
+			missingReferences: []UsageMissingReference{},
+		},
+		"unused": {
+			ids:               []int64{1, 2},
+			hosts:             map[int64][]HostReference{},
+			unused:            []int64{1, 2},
+			counts:            map[string]int64{"1": 0, "2": 0},
+			missingReferences: []UsageMissingReference{},
+		},
+		"used": {
+			ids: []int64{1, 2},
+			hosts: map[int64][]HostReference{
+				1: {proxyHost, stream},
+			},
+			unused:            []int64{2},
+			counts:            map[string]int64{"1": 2, "2": 0},
+			missingReferences: []UsageMissingReference{},
+		},
+		"missing": {
+			ids: []int64{1},
+			hosts: map[int64][]HostReference{
+				1: {proxyHost},
+				3: {stream},
+			},
+			unused: []int64{},
+			counts: map[string]int64{"1": 1},
+			missingReferences: []UsageMissingReference{
+				{
+					HostType:   types.StringValue("stream"),
+					HostId:     types.Int64Value(2),
+					HostName:   types.StringValue("port 8443"),
+					ObjectType: types.StringValue("certificate"),
+					ObjectId:   types.Int64Value(3),
+				},
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			unused, counts, missingReferences := usageOf("certificate", test.ids, test.hosts, []UsageMissingReference{})
+
+			if !reflect.DeepEqual(unused, test.unused) {
+				t.Errorf("expected unused %v, got %v", test.unused, unused)
+			}
+			if !reflect.DeepEqual(counts, test.counts) {
+				t.Errorf("expected counts %v, got %v", test.counts, counts)
+			}
+			if !reflect.DeepEqual(missingReferences, test.missingReferences) {
+				t.Errorf("expected missing references %v, got %v", test.missingReferences, missingReferences)
+			}
+		})
+	}
+}
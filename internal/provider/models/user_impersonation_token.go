@@ -0,0 +1,22 @@
+// Copyright (c) Sander Jochems
+// SPDX-License-Identifier: MIT
+
+package models
+
+import (
+	"context"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/sander0542/nginxproxymanager-go"
+)
+
+type UserImpersonationToken struct {
+	UserId  types.Int64  `tfsdk:"user_id"`
+	Token   types.String `tfsdk:"token"`
+	Expires types.String `tfsdk:"expires"`
+}
+
+func (m *UserImpersonationToken) Write(_ context.Context, login *nginxproxymanager.LoginAsUser200Response, _ *diag.Diagnostics) {
+	m.Token = types.StringValue(login.GetToken())
+	m.Expires = types.StringValue(login.GetExpires())
+}
@@ -6,5 +6,8 @@ package models
 import "github.com/hashicorp/terraform-plugin-framework/types"
 
 type UserToken struct {
-	Token types.String `tfsdk:"token"`
+	Expiry  types.String `tfsdk:"expiry"`
+	Scope   types.String `tfsdk:"scope"`
+	Token   types.String `tfsdk:"token"`
+	Expires types.String `tfsdk:"expires"`
 }
@@ -0,0 +1,125 @@
+// Copyright (c) Sander Jochems
+// SPDX-License-Identifier: MIT
+
+package models
+
+import (
+	"crypto"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ParseCertificates parses all PEM encoded certificates in the content, in the order they appear.
+func ParseCertificates(content string) ([]*x509.Certificate, error) {
+	var certificates []*x509.Certificate
+
+	rest := []byte(content)
+	for {
+		var block *pem.Block
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			break
+		}
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+
+		certificate, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("unable to parse certificate %d: %w", len(certificates)+1, err)
+		}
+
+		certificates = append(certificates, certificate)
+	}
+
+	if len(certificates) == 0 {
+		return nil, errors.New("no PEM encoded certificate found")
+	}
+
+	return certificates, nil
+}
+
+// ParsePrivateKey parses a PEM encoded PKCS #1, PKCS #8 or SEC 1 private key.
+func ParsePrivateKey(content string) (crypto.Signer, error) {
+	rest := []byte(content)
+	for {
+		var block *pem.Block
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			return nil, errors.New("no PEM encoded private key found")
+		}
+
+		var key any
+		var err error
+		switch block.Type {
+		case "RSA PRIVATE KEY":
+			key, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+		case "EC PRIVATE KEY":
+			key, err = x509.ParseECPrivateKey(block.Bytes)
+		case "PRIVATE KEY":
+			key, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+		default:
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("unable to parse private key: %w", err)
+		}
+
+		signer, ok := key.(crypto.Signer)
+		if !ok {
+			return nil, fmt.Errorf("unsupported private key type %T", key)
+		}
+
+		return signer, nil
+	}
+}
+
+// PrivateKeyMatches returns whether the private key belongs to the public key of the certificate.
+func PrivateKeyMatches(certificate *x509.Certificate, key crypto.Signer) bool {
+	publicKey, ok := key.Public().(interface{ Equal(crypto.PublicKey) bool })
+
+	return ok && publicKey.Equal(certificate.PublicKey)
+}
+
+// VerifyChainOrder verifies that every certificate is signed by the certificate that follows it.
+func VerifyChainOrder(certificates []*x509.Certificate) error {
+	for i := 0; i < len(certificates)-1; i++ {
+		if err := certificates[i].CheckSignatureFrom(certificates[i+1]); err != nil {
+			return fmt.Errorf("certificate %d (%s) is not signed by certificate %d (%s), the chain must be ordered from the leaf certificate to the root: %w", i+1, certificates[i].Subject.CommonName, i+2, certificates[i+1].Subject.CommonName, err)
+		}
+	}
+
+	return nil
+}
+
+// CertificateDomainNames returns the DNS names of the certificate, falling back to the common name when the
+// certificate has no subject alternative names.
+func CertificateDomainNames(certificate *x509.Certificate) []string {
+	if len(certificate.DNSNames) > 0 {
+		domainNames := make([]string, 0, len(certificate.DNSNames))
+		for _, domainName := range certificate.DNSNames {
+			domainNames = append(domainNames, strings.ToLower(domainName))
+		}
+
+		return domainNames
+	}
+
+	if certificate.Subject.CommonName != "" {
+		return []string{strings.ToLower(certificate.Subject.CommonName)}
+	}
+
+	return []string{}
+}
+
+// CertificateSubjectAlternativeNames returns the DNS names and IP addresses of the certificate.
+func CertificateSubjectAlternativeNames(certificate *x509.Certificate) []string {
+	subjectAlternativeNames := append([]string{}, certificate.DNSNames...)
+	for _, ipAddress := range certificate.IPAddresses {
+		subjectAlternativeNames = append(subjectAlternativeNames, ipAddress.String())
+	}
+
+	return subjectAlternativeNames
+}
@@ -0,0 +1,382 @@
+// Copyright (c) Sander Jochems
+// SPDX-License-Identifier: MIT
+
+package models
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+type testIssuer struct {
+	certificate *x509.Certificate
+	key         crypto.Signer
+}
+
+func testRsaKey(t *testing.T) *rsa.PrivateKey {
+	t.Helper()
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("unable to generate RSA key: %s", err)
+	}
+
+	return key
+}
+
+func testEcKey(t *testing.T) *ecdsa.PrivateKey {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("unable to generate EC key: %s", err)
+	}
+
+	return key
+}
+
+// testCertificate creates a certificate for the key from the template, signed by the issuer or self-signed when the
+// issuer is nil.
+func testCertificate(t *testing.T, template *x509.Certificate, key crypto.Signer, issuer *testIssuer) *x509.Certificate {
+	t.Helper()
+
+	template.SerialNumber = big.NewInt(time.Now().UnixNano())
+	template.NotBefore = time.Now().Add(-time.Hour)
+	template.NotAfter = time.Now().Add(time.Hour)
+
+	parent, parentKey := template, key
+	if issuer != nil {
+		parent, parentKey = issuer.certificate, issuer.key
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, parent, key.Public(), parentKey)
+	if err != nil {
+		t.Fatalf("unable to create certificate: %s", err)
+	}
+
+	certificate, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatalf("unable to parse certificate: %s", err)
+	}
+
+	return certificate
+}
+
+func testCaTemplate(commonName string) *x509.Certificate {
+	return &x509.Certificate{
+		Subject:               pkix.Name{CommonName: commonName},
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}
+}
+
+func testPem(blockType string, bytes []byte) string {
+	return string(pem.EncodeToMemory(&pem.Block{Type: blockType, Bytes: bytes}))
+}
+
+func testPkcs8Pem(t *testing.T, key crypto.Signer) string {
+	t.Helper()
+
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		t.Fatalf("unable to marshal key: %s", err)
+	}
+
+	return testPem("PRIVATE KEY", der)
+}
+
+func TestParseCertificates(t *testing.T) {
+	key := testEcKey(t)
+	first := testCertificate(t, &x509.Certificate{Subject: pkix.Name{CommonName: "first.example.com"}}, key, nil)
+	second := testCertificate(t, &x509.Certificate{Subject: pkix.Name{CommonName: "second.example.com"}}, key, nil)
+
+	keyDer, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatalf("unable to marshal key: %s", err)
+	}
+
+	tests := map[string]struct {
+		content     string
+		commonNames []string
+		err         string
+	}{
+		"single": {
+			content:     testPem("CERTIFICATE", first.Raw),
+			commonNames: []string{"first.example.com"},
+		},
+		"order": {
+			content:     testPem("CERTIFICATE", second.Raw) + testPem("CERTIFICATE", first.Raw),
+			commonNames: []string{"second.example.com", "first.example.com"},
+		},
+		"other-blocks": {
+			content:     testPem("EC PRIVATE KEY", keyDer) + testPem("CERTIFICATE", first.Raw),
+			commonNames: []string{"first.example.com"},
+		},
+		"empty": {
+			content: "",
+			err:     "no PEM encoded certificate found",
+		},
+		"key-only": {
+			content: testPem("EC PRIVATE KEY", keyDer),
+			err:     "no PEM encoded certificate found",
+		},
+		"invalid": {
+			content: testPem("CERTIFICATE", first.Raw) + testPem("CERTIFICATE", []byte("invalid")),
+			err:     "unable to parse certificate 2",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			certificates, err := ParseCertificates(test.content)
+			if test.err != "" {
+				if err == nil || !strings.Contains(err.Error(), test.err) {
+					t.Fatalf("expected error containing %q, got %v", test.err, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			commonNames := make([]string, 0, len(certificates))
+			for _, certificate := range certificates {
+				commonNames = append(commonNames, certificate.Subject.CommonName)
+			}
+			if !reflect.DeepEqual(commonNames, test.commonNames) {
+				t.Errorf("expected %v, got %v", test.commonNames, commonNames)
+			}
+		})
+	}
+}
+
+func TestParsePrivateKey(t *testing.T) {
+	rsaKey := testRsaKey(t)
+	ecKey := testEcKey(t)
+
+	ecDer, err := x509.MarshalECPrivateKey(ecKey)
+	if err != nil {
+		t.Fatalf("unable to marshal key: %s", err)
+	}
+
+	certificate := testCertificate(t, &x509.Certificate{Subject: pkix.Name{CommonName: "example.com"}}, ecKey, nil)
+
+	tests := map[string]struct {
+		content string
+		key     crypto.Signer
+		err     string
+	}{
+		"rsa-pkcs1": {
+			content: testPem("RSA PRIVATE KEY", x509.MarshalPKCS1PrivateKey(rsaKey)),
+			key:     rsaKey,
+		},
+		"rsa-pkcs8": {
+			content: testPkcs8Pem(t, rsaKey),
+			key:     rsaKey,
+		},
+		"ec-sec1": {
+			content: testPem("EC PRIVATE KEY", ecDer),
+			key:     ecKey,
+		},
+		"ec-pkcs8": {
+			content: testPkcs8Pem(t, ecKey),
+			key:     ecKey,
+		},
+		"after-certificate": {
+			content: testPem("CERTIFICATE", certificate.Raw) + testPem("EC PRIVATE KEY", ecDer),
+			key:     ecKey,
+		},
+		"empty": {
+			content: "",
+			err:     "no PEM encoded private key found",
+		},
+		"certificate-only": {
+			content: testPem("CERTIFICATE", certificate.Raw),
+			err:     "no PEM encoded private key found",
+		},
+		"invalid": {
+			content: testPem("RSA PRIVATE KEY", []byte("invalid")),
+			err:     "unable to parse private key",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			key, err := ParsePrivateKey(test.content)
+			if test.err != "" {
+				if err == nil || !strings.Contains(err.Error(), test.err) {
+					t.Fatalf("expected error containing %q, got %v", test.err, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			publicKey, ok := key.Public().(interface{ Equal(crypto.PublicKey) bool })
+			if !ok || !publicKey.Equal(test.key.Public()) {
+				t.Errorf("parsed key does not match the original key")
+			}
+		})
+	}
+}
+
+func TestPrivateKeyMatches(t *testing.T) {
+	rsaKey := testRsaKey(t)
+	ecKey := testEcKey(t)
+	otherEcKey := testEcKey(t)
+
+	rsaCertificate := testCertificate(t, &x509.Certificate{Subject: pkix.Name{CommonName: "rsa.example.com"}}, rsaKey, nil)
+	ecCertificate := testCertificate(t, &x509.Certificate{Subject: pkix.Name{CommonName: "ec.example.com"}}, ecKey, nil)
+
+	tests := map[string]struct {
+		certificate *x509.Certificate
+		key         crypto.Signer
+		expected    bool
+	}{
+		"rsa": {
+			certificate: rsaCertificate,
+			key:         rsaKey,
+			expected:    true,
+		},
+		"ec": {
+			certificate: ecCertificate,
+			key:         ecKey,
+			expected:    true,
+		},
+		"mismatch": {
+			certificate: ecCertificate,
+			key:         otherEcKey,
+			expected:    false,
+		},
+		"mismatch-type": {
+			certificate: rsaCertificate,
+			key:         ecKey,
+			expected:    false,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			if actual := PrivateKeyMatches(test.certificate, test.key); actual != test.expected {
+				t.Errorf("expected %t, got %t", test.expected, actual)
+			}
+		})
+	}
+}
+
+func TestVerifyChainOrder(t *testing.T) {
+	rootKey := testEcKey(t)
+	root := testCertificate(t, testCaTemplate("Root"), rootKey, nil)
+
+	intermediateKey := testEcKey(t)
+	intermediate := testCertificate(t, testCaTemplate("Intermediate"), intermediateKey, &testIssuer{root, rootKey})
+
+	leaf := testCertificate(t, &x509.Certificate{Subject: pkix.Name{CommonName: "example.com"}}, testEcKey(t), &testIssuer{intermediate, intermediateKey})
+
+	tests := map[string]struct {
+		certificates []*x509.Certificate
+		err          string
+	}{
+		"single": {
+			certificates: []*x509.Certificate{leaf},
+		},
+		"ordered": {
+			certificates: []*x509.Certificate{leaf, intermediate, root},
+		},
+		"ordered-without-root": {
+			certificates: []*x509.Certificate{leaf, intermediate},
+		},
+		"wrong-order": {
+			certificates: []*x509.Certificate{leaf, root, intermediate},
+			err:          "certificate 1 (example.com) is not signed by certificate 2 (Root)",
+		},
+		"reversed": {
+			certificates: []*x509.Certificate{root, intermediate, leaf},
+			err:          "certificate 1 (Root) is not signed by certificate 2 (Intermediate)",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			err := VerifyChainOrder(test.certificates)
+			if test.err == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), test.err) {
+				t.Fatalf("expected error containing %q, got %v", test.err, err)
+			}
+		})
+	}
+}
+
+func TestCertificateDomainNames(t *testing.T) {
+	key := testEcKey(t)
+
+	tests := map[string]struct {
+		template                *x509.Certificate
+		domainNames             []string
+		subjectAlternativeNames []string
+	}{
+		"dns-names": {
+			template: &x509.Certificate{
+				Subject:  pkix.Name{CommonName: "ignored.example.com"},
+				DNSNames: []string{"Example.com", "*.EXAMPLE.com"},
+			},
+			domainNames:             []string{"example.com", "*.example.com"},
+			subjectAlternativeNames: []string{"Example.com", "*.EXAMPLE.com"},
+		},
+		"common-name": {
+			template: &x509.Certificate{
+				Subject: pkix.Name{CommonName: "WWW.example.com"},
+			},
+			domainNames:             []string{"www.example.com"},
+			subjectAlternativeNames: []string{},
+		},
+		"ip-address": {
+			template: &x509.Certificate{
+				Subject:     pkix.Name{CommonName: "example.com"},
+				DNSNames:    []string{"example.com"},
+				IPAddresses: []net.IP{net.ParseIP("192.0.2.1"), net.ParseIP("2001:db8::1")},
+			},
+			domainNames:             []string{"example.com"},
+			subjectAlternativeNames: []string{"example.com", "192.0.2.1", "2001:db8::1"},
+		},
+		"ip-address-only": {
+			template: &x509.Certificate{
+				IPAddresses: []net.IP{net.ParseIP("192.0.2.1")},
+			},
+			domainNames:             []string{},
+			subjectAlternativeNames: []string{"192.0.2.1"},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			certificate := testCertificate(t, test.template, key, nil)
+
+			if actual := CertificateDomainNames(certificate); !reflect.DeepEqual(actual, test.domainNames) {
+				t.Errorf("expected domain names %v, got %v", test.domainNames, actual)
+			}
+			if actual := CertificateSubjectAlternativeNames(certificate); !reflect.DeepEqual(actual, test.subjectAlternativeNames) {
+				t.Errorf("expected subject alternative names %v, got %v", test.subjectAlternativeNames, actual)
+			}
+		})
+	}
+}
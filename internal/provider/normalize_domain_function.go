@@ -0,0 +1,55 @@
+// Copyright (c) Sander Jochems
+// SPDX-License-Identifier: MIT
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/sander0542/terraform-provider-nginxproxymanager/internal/provider/models"
+)
+
+var _ function.Function = &NormalizeDomainFunction{}
+
+func NewNormalizeDomainFunction() function.Function {
+	return &NormalizeDomainFunction{}
+}
+
+type NormalizeDomainFunction struct{}
+
+func (f *NormalizeDomainFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "normalize_domain"
+}
+
+func (f *NormalizeDomainFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:             "Hosts --- Returns a domain name in the form used to compare domain names.",
+		MarkdownDescription: "Hosts --- Returns the domain name in lowercase, without a trailing dot and with internationalized labels converted to punycode, so `Bücher.Example.com.` becomes `xn--bcher-kva.example.com`. A leading wildcard label is kept.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "name",
+				MarkdownDescription: "The domain name to normalize.",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *NormalizeDomainFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var domainName string
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, req.Arguments.Get(ctx, &domainName))
+
+	if resp.Error != nil {
+		return
+	}
+
+	normalized, err := models.NormalizeDomain(domainName)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, fmt.Sprintf("Unable to normalize domain name %q, got error: %s", domainName, err))
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, normalized))
+}
@@ -0,0 +1,60 @@
+// Copyright (c) Sander Jochems
+// SPDX-License-Identifier: MIT
+
+package provider
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func TestNormalizeDomainFunction(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+output "mixed_case" {
+	value = provider::nginxproxymanager::normalize_domain("App.EXAMPLE.com.")
+}
+
+output "internationalized" {
+	value = provider::nginxproxymanager::normalize_domain("Bücher.example.com")
+}
+
+output "underscore" {
+	value = provider::nginxproxymanager::normalize_domain("_acme-challenge.example.com")
+}
+
+output "wildcard" {
+	value = provider::nginxproxymanager::normalize_domain("*.Example.com")
+}
+`,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownOutputValue("mixed_case", knownvalue.StringExact("app.example.com")),
+					statecheck.ExpectKnownOutputValue("internationalized", knownvalue.StringExact("xn--bcher-kva.example.com")),
+					statecheck.ExpectKnownOutputValue("underscore", knownvalue.StringExact("_acme-challenge.example.com")),
+					statecheck.ExpectKnownOutputValue("wildcard", knownvalue.StringExact("*.example.com")),
+				},
+			},
+			// Invalid domain name
+			{
+				Config: `
+output "invalid" {
+	value = provider::nginxproxymanager::normalize_domain("` + strings.Repeat("a", 64) + `.example.com")
+}
+`,
+				ExpectError: regexp.MustCompile("Unable to normalize domain name"),
+			},
+		},
+	})
+}
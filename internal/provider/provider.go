@@ -9,10 +9,12 @@ import (
 	"os"
 	"sync"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/providervalidator"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/sander0542/nginxproxymanager-go"
 
+	"github.com/hashicorp/terraform-plugin-framework/action"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
 	"github.com/hashicorp/terraform-plugin-framework/function"
@@ -26,6 +28,8 @@ import (
 var _ provider.Provider = &NginxProxyManagerProvider{}
 var _ provider.ProviderWithFunctions = &NginxProxyManagerProvider{}
 var _ provider.ProviderWithEphemeralResources = &NginxProxyManagerProvider{}
+var _ provider.ProviderWithActions = &NginxProxyManagerProvider{}
+var _ provider.ProviderWithConfigValidators = &NginxProxyManagerProvider{}
 
 // NginxProxyManagerProvider defines the provider implementation.
 type NginxProxyManagerProvider struct {
@@ -40,12 +44,18 @@ type NginxProxyManagerProviderModel struct {
 	Url      types.String `tfsdk:"url"`
 	Username types.String `tfsdk:"username"`
 	Password types.String `tfsdk:"password"`
+	Token    types.String `tfsdk:"token"`
+
+	StrictCertificateCoverage types.Bool `tfsdk:"strict_certificate_coverage"`
 }
 
 type NginxProxyManagerProviderData struct {
 	Client           *nginxproxymanager.APIClient
 	Auth             context.Context
 	CertificateMutex sync.Mutex
+	Certificates     certificateList
+
+	StrictCertificateCoverage bool
 }
 
 func (p *NginxProxyManagerProvider) Metadata(ctx context.Context, req provider.MetadataRequest, resp *provider.MetadataResponse) {
@@ -70,10 +80,26 @@ func (p *NginxProxyManagerProvider) Schema(ctx context.Context, req provider.Sch
 				Optional:            true,
 				Sensitive:           true,
 			},
+			"token": schema.StringAttribute{
+				MarkdownDescription: "Token for Nginx Proxy Manager authentication, for example from the `nginxproxymanager_user_impersonation_token` ephemeral resource. Conflicts with `username` and `password`. Can be specified via the `NGINXPROXYMANAGER_TOKEN` environment variable, which is only used when `username` and `password` are not configured.",
+				Optional:            true,
+				Sensitive:           true,
+			},
+			"strict_certificate_coverage": schema.BoolAttribute{
+				MarkdownDescription: "Whether a host with a certificate that does not cover all of its domain names results in an error during plan, instead of a warning. Defaults to `false`.",
+				Optional:            true,
+			},
 		},
 	}
 }
 
+func (p *NginxProxyManagerProvider) ConfigValidators(ctx context.Context) []provider.ConfigValidator {
+	return []provider.ConfigValidator{
+		providervalidator.Conflicting(path.MatchRoot("token"), path.MatchRoot("username")),
+		providervalidator.Conflicting(path.MatchRoot("token"), path.MatchRoot("password")),
+	}
+}
+
 func (p *NginxProxyManagerProvider) Configure(ctx context.Context, req provider.ConfigureRequest, resp *provider.ConfigureResponse) {
 
 	var data NginxProxyManagerProviderModel
@@ -104,6 +130,49 @@ func (p *NginxProxyManagerProvider) Configure(ctx context.Context, req provider.
 	}
 	parsedUrl = parsedUrl.JoinPath("/api")
 
+	// Token
+	token := data.Token.ValueString()
+	if token == "" && data.Username.IsNull() && data.Password.IsNull() {
+		tflog.Trace(ctx, "Token and credentials are not set in configuration, checking environment variables")
+		token = os.Getenv("NGINXPROXYMANAGER_TOKEN")
+	}
+
+	tflog.Info(ctx, "Initializing the Nginx Proxy Manager API client")
+
+	config := nginxproxymanager.NewConfiguration()
+	config.Servers[0].URL = parsedUrl.String()
+	client := nginxproxymanager.NewAPIClient(config)
+
+	auth := context.Background()
+
+	if token == "" {
+		token = p.requestToken(ctx, &data, client, auth, resp)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	} else {
+		tflog.MaskMessageStrings(ctx, token)
+		tflog.Info(ctx, "Using the configured token for the Nginx Proxy Manager API")
+	}
+
+	auth = context.WithValue(auth, nginxproxymanager.ContextAccessToken, token)
+
+	providerData := NginxProxyManagerProviderData{
+		Auth:   auth,
+		Client: client,
+
+		StrictCertificateCoverage: data.StrictCertificateCoverage.ValueBool(),
+	}
+
+	resp.DataSourceData = &providerData
+	resp.ResourceData = &providerData
+	resp.EphemeralResourceData = &providerData
+	resp.ActionData = &providerData
+
+	tflog.Info(ctx, "Successfully initialized the Nginx Proxy Manager API client")
+}
+
+func (p *NginxProxyManagerProvider) requestToken(ctx context.Context, data *NginxProxyManagerProviderModel, client *nginxproxymanager.APIClient, auth context.Context, resp *provider.ConfigureResponse) string {
 	// Username
 	username := data.Username.ValueString()
 	if username == "" {
@@ -138,18 +207,10 @@ func (p *NginxProxyManagerProvider) Configure(ctx context.Context, req provider.
 
 	if resp.Diagnostics.HasError() {
 		tflog.Trace(ctx, "Failed to load provider configuration")
-		return
+		return ""
 	}
 
 	tflog.MaskMessageStrings(ctx, username, password)
-	tflog.Info(ctx, "Initializing the Nginx Proxy Manager API client")
-
-	config := nginxproxymanager.NewConfiguration()
-	config.Servers[0].URL = parsedUrl.String()
-	client := nginxproxymanager.NewAPIClient(config)
-
-	auth := context.Background()
-
 	tflog.Info(ctx, "Authenticating with the Nginx Proxy Manager API")
 
 	tokenRequest := nginxproxymanager.RequestTokenRequest{
@@ -160,23 +221,12 @@ func (p *NginxProxyManagerProvider) Configure(ctx context.Context, req provider.
 	tokenResponse, _, err := client.TokensAPI.RequestToken(auth).RequestTokenRequest(tokenRequest).Execute()
 	if err != nil {
 		resp.Diagnostics.AddError("Failed to authenticate with the Nginx Proxy Manager API", err.Error())
-		return
+		return ""
 	}
 
 	tflog.Info(ctx, "Successfully authenticated with the Nginx Proxy Manager API")
 
-	auth = context.WithValue(auth, nginxproxymanager.ContextAccessToken, tokenResponse.GetToken())
-
-	providerData := NginxProxyManagerProviderData{
-		Auth:   auth,
-		Client: client,
-	}
-
-	resp.DataSourceData = &providerData
-	resp.ResourceData = &providerData
-	resp.EphemeralResourceData = &providerData
-
-	tflog.Info(ctx, "Successfully initialized the Nginx Proxy Manager API client")
+	return tokenResponse.GetToken()
 }
 
 func (p *NginxProxyManagerProvider) Resources(ctx context.Context) []func() resource.Resource {
@@ -194,15 +244,26 @@ func (p *NginxProxyManagerProvider) Resources(ctx context.Context) []func() reso
 
 func (p *NginxProxyManagerProvider) EphemeralResources(ctx context.Context) []func() ephemeral.EphemeralResource {
 	return []func() ephemeral.EphemeralResource{
+		NewCertificatePrivateKeyEphemeralResource,
+		NewUserImpersonationTokenEphemeralResource,
 		NewUserTokenEphemeralResource,
 	}
 }
 
+func (p *NginxProxyManagerProvider) Actions(ctx context.Context) []func() action.Action {
+	return []func() action.Action{
+		NewCertificateRenewAction,
+		NewCertificateTestHttpAction,
+		NewHostToggleAction,
+	}
+}
+
 func (p *NginxProxyManagerProvider) DataSources(ctx context.Context) []func() datasource.DataSource {
 	return []func() datasource.DataSource{
 		NewAccessListDataSource,
 		NewAccessListsDataSource,
 		NewCertificateDataSource,
+		NewCertificateMaterialDataSource,
 		NewCertificatesDataSource,
 		NewDeadHostDataSource,
 		NewDeadHostsDataSource,
@@ -213,6 +274,7 @@ func (p *NginxProxyManagerProvider) DataSources(ctx context.Context) []func() da
 		NewSettingsDataSource,
 		NewStreamDataSource,
 		NewStreamsDataSource,
+		NewUsageDataSource,
 		NewUserDataSource,
 		NewUserMeDataSource,
 		NewUsersDataSource,
@@ -221,7 +283,12 @@ func (p *NginxProxyManagerProvider) DataSources(ctx context.Context) []func() da
 }
 
 func (p *NginxProxyManagerProvider) Functions(ctx context.Context) []func() function.Function {
-	return []func() function.Function{}
+	return []func() function.Function{
+		NewCertificateInfoFunction,
+		NewDomainMatchesFunction,
+		NewDomainsCoveredFunction,
+		NewNormalizeDomainFunction,
+	}
 }
 
 func New(version string) func() provider.Provider {
@@ -5,11 +5,16 @@ package provider
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/boolvalidator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/objectvalidator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
@@ -24,10 +29,19 @@ import (
 	"github.com/sander0542/nginxproxymanager-go"
 	"github.com/sander0542/terraform-provider-nginxproxymanager/internal/provider/models"
 	"strconv"
+	"sync"
+	"time"
 )
 
 var _ resource.Resource = &ProxyHostResource{}
 var _ resource.ResourceWithImportState = &ProxyHostResource{}
+var _ resource.ResourceWithModifyPlan = &ProxyHostResource{}
+var _ resource.ResourceWithValidateConfig = &ProxyHostResource{}
+var _ resource.ResourceWithConfigValidators = &ProxyHostResource{}
+
+// letsencryptCertificateKey is the private state key of the certificate the letsencrypt block requested, so only that
+// certificate is ever deleted, even when certificate_id was changed outside of Terraform.
+const letsencryptCertificateKey = "letsencrypt_certificate_id"
 
 func NewProxyHostResource() resource.Resource {
 	return &ProxyHostResource{}
@@ -36,6 +50,11 @@ func NewProxyHostResource() resource.Resource {
 type ProxyHostResource struct {
 	client *nginxproxymanager.APIClient
 	auth   context.Context
+
+	certificates *certificateList
+	mutex        *sync.Mutex
+
+	strictCertificateCoverage bool
 }
 
 func (r *ProxyHostResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -65,10 +84,12 @@ func (r *ProxyHostResource) Schema(ctx context.Context, req resource.SchemaReque
 				Computed:            true,
 			},
 			"owner_user_id": schema.Int64Attribute{
-				MarkdownDescription: "The Id of the user that owns the proxy host.",
+				MarkdownDescription: "The Id of the user that owns the proxy host. When set, the proxy host is created on behalf of this user. Changing the owner forces a new proxy host to be created, as Nginx Proxy Manager cannot reassign the owner of an existing proxy host.",
 				Computed:            true,
+				Optional:            true,
 				PlanModifiers: []planmodifier.Int64{
 					int64planmodifier.UseStateForUnknown(),
+					int64planmodifier.RequiresReplace(),
 				},
 			},
 			"domain_names": schema.SetAttribute{
@@ -95,9 +116,11 @@ func (r *ProxyHostResource) Schema(ctx context.Context, req resource.SchemaReque
 				},
 			},
 			"certificate_id": schema.Int64Attribute{
-				MarkdownDescription: "The Id of the certificate used by the proxy host.",
+				MarkdownDescription: "The Id of the certificate used by the proxy host. Can only be configured when `certificate_selection` is `manual`, otherwise the selected certificate is computed.",
+				Computed:            true,
 				Optional:            true,
 			},
+			"certificate_selection": certificateSelectionAttribute("domain_names"),
 			"ssl_forced": schema.BoolAttribute{
 				MarkdownDescription: "Whether SSL is forced for the proxy host.",
 				Computed:            true,
@@ -200,6 +223,64 @@ func (r *ProxyHostResource) Schema(ctx context.Context, req resource.SchemaReque
 				},
 			},
 		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Update: true,
+			}),
+			"letsencrypt": schema.SingleNestedBlock{
+				MarkdownDescription: "Request a Let's Encrypt certificate for the domain names of the proxy host. The certificate is linked to the proxy host, requested again when the domain names or these settings change, and deleted together with the proxy host unless other hosts use it. When `certificate_id` is changed outside of Terraform, it is planned back to the requested certificate. Conflicts with `certificate_id` and automatic `certificate_selection`.",
+				// The attributes cannot be required themselves, as that would also require them when the block is not set.
+				Validators: []validator.Object{
+					objectvalidator.AlsoRequires(
+						path.MatchRelative().AtName("email"),
+						path.MatchRelative().AtName("agree"),
+					),
+				},
+				Attributes: map[string]schema.Attribute{
+					"email": schema.StringAttribute{
+						MarkdownDescription: "The email address to use for the Let's Encrypt certificate. Required when the block is set.",
+						Optional:            true,
+					},
+					"agree": schema.BoolAttribute{
+						MarkdownDescription: "Whether you agree to the [Let's Encrypt Terms of Service](https://letsencrypt.org/repository/). Required when the block is set.",
+						Optional:            true,
+						Validators: []validator.Bool{
+							boolvalidator.Equals(true),
+						},
+					},
+					"dns_challenge": schema.BoolAttribute{
+						MarkdownDescription: "Whether to use a DNS challenge instead of an HTTP challenge. Required for wildcard domain names. Defaults to `false`.",
+						Optional:            true,
+					},
+					"dns_provider": schema.StringAttribute{
+						MarkdownDescription: "The DNS provider to use for the DNS challenge, such as `cloudflare` or `route53`.",
+						Optional:            true,
+						Validators: []validator.String{
+							stringvalidator.OneOf(dnsProviderIds()...),
+						},
+					},
+					"dns_credentials": schema.MapAttribute{
+						MarkdownDescription: "The credentials for the DNS provider, as key value pairs.",
+						Optional:            true,
+						Sensitive:           true,
+						ElementType:         types.StringType,
+						Validators:          dnsCredentialsValidators(),
+					},
+					"propagation_seconds": schema.Int64Attribute{
+						MarkdownDescription: "The number of seconds to wait for the DNS records to propagate.",
+						Optional:            true,
+						Validators: []validator.Int64{
+							int64validator.AtLeast(0),
+						},
+					},
+					"preflight_http_check": schema.BoolAttribute{
+						MarkdownDescription: "Whether to test that the domain names are reachable over HTTP before requesting a certificate without DNS challenge. This prevents hitting the Let's Encrypt rate limits for domain names that do not point to Nginx Proxy Manager. Defaults to `false`.",
+						Optional:            true,
+					},
+				},
+			},
+		},
 	}
 }
 
@@ -207,11 +288,14 @@ func (r *ProxyHostResource) Configure(ctx context.Context, req resource.Configur
 	if data := resourceConfigure(ctx, req, resp); data != nil {
 		r.client = data.Client
 		r.auth = data.Auth
+		r.certificates = &data.Certificates
+		r.mutex = &data.CertificateMutex
+		r.strictCertificateCoverage = data.StrictCertificateCoverage
 	}
 }
 
 func (r *ProxyHostResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
-	var data *models.ProxyHost
+	var data *models.ProxyHostResource
 
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
 
@@ -221,10 +305,36 @@ func (r *ProxyHostResource) Create(ctx context.Context, req resource.CreateReque
 
 	hostEnabled := data.Enabled.ValueBool()
 
+	createAuth, err := ownerAuth(r.client, r.auth, data.OwnerUserId)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("owner_user_id"), "Client Error", fmt.Sprintf("Unable to log in as owner, got error: %s", err))
+		return
+	}
+
+	if !data.Letsencrypt.IsNull() {
+		createTimeout, diags := data.Timeouts.Create(ctx, defaultCertificateCreateTimeout)
+		resp.Diagnostics.Append(diags...)
+
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		data.CertificateId = r.createCertificate(ctx, createAuth, data, createTimeout, &resp.Diagnostics)
+
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
 	request := data.ToCreateRequest(ctx, &resp.Diagnostics)
-	proxyHost, _, err := r.client.ProxyHostsAPI.CreateProxyHost(r.auth).CreateProxyHostRequest(*request).Execute()
+	proxyHost, _, err := r.client.ProxyHostsAPI.CreateProxyHost(createAuth).CreateProxyHostRequest(*request).Execute()
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create proxy host, got error: %s", err))
+
+		// Do not leave the certificate behind when the proxy host could not be created.
+		if !data.Letsencrypt.IsNull() {
+			r.deleteCertificate(data.CertificateId, &resp.Diagnostics)
+		}
 		return
 	}
 
@@ -232,7 +342,11 @@ func (r *ProxyHostResource) Create(ctx context.Context, req resource.CreateReque
 
 	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), data.Id)...)
 
-	err = r.toggleHost(proxyHost.GetId(), proxyHost.GetEnabled(), hostEnabled)
+	if !data.Letsencrypt.IsNull() {
+		setLetsencryptCertificateId(ctx, resp.Private, data.CertificateId, &resp.Diagnostics)
+	}
+
+	err = toggleProxyHost(r.client, r.auth, proxyHost.GetId(), proxyHost.GetEnabled(), hostEnabled)
 	if err != nil {
 		resp.Diagnostics.AddAttributeError(path.Root("enabled"), "Client Error", fmt.Sprintf("Unable to update proxy host, got err: %s", err))
 		return
@@ -244,7 +358,7 @@ func (r *ProxyHostResource) Create(ctx context.Context, req resource.CreateReque
 }
 
 func (r *ProxyHostResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
-	var data *models.ProxyHost
+	var data *models.ProxyHostResource
 
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
 
@@ -269,7 +383,7 @@ func (r *ProxyHostResource) Read(ctx context.Context, req resource.ReadRequest,
 }
 
 func (r *ProxyHostResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	var data *models.ProxyHost
+	var data *models.ProxyHostResource
 
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
 
@@ -278,17 +392,51 @@ func (r *ProxyHostResource) Update(ctx context.Context, req resource.UpdateReque
 	}
 
 	hostEnabled := data.Enabled.ValueBool()
+	requestedCertificateId := letsencryptCertificateId(ctx, req.Private, &resp.Diagnostics)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// The plan marks the certificate Id as unknown when a new certificate has to be requested.
+	created := false
+	if !data.Letsencrypt.IsNull() && data.CertificateId.IsUnknown() {
+		createAuth, err := ownerAuth(r.client, r.auth, data.OwnerUserId)
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("owner_user_id"), "Client Error", fmt.Sprintf("Unable to log in as owner, got error: %s", err))
+			return
+		}
+
+		updateTimeout, diags := data.Timeouts.Update(ctx, defaultCertificateCreateTimeout)
+		resp.Diagnostics.Append(diags...)
+
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		data.CertificateId = r.createCertificate(ctx, createAuth, data, updateTimeout, &resp.Diagnostics)
+
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		created = true
+	}
 
 	request := data.ToUpdateRequest(ctx, &resp.Diagnostics)
 	proxyHost, _, err := r.client.ProxyHostsAPI.UpdateProxyHost(r.auth, data.Id.ValueInt64()).UpdateProxyHostRequest(*request).Execute()
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update proxy host, got error: %s", err))
+
+		// Do not leave a newly requested certificate behind when the proxy host could not be updated.
+		if created {
+			r.deleteCertificate(data.CertificateId, &resp.Diagnostics)
+		}
 		return
 	}
 
 	data.Write(ctx, proxyHost, &resp.Diagnostics)
 
-	err = r.toggleHost(proxyHost.GetId(), proxyHost.GetEnabled(), hostEnabled)
+	err = toggleProxyHost(r.client, r.auth, proxyHost.GetId(), proxyHost.GetEnabled(), hostEnabled)
 	if err != nil {
 		resp.Diagnostics.AddAttributeError(path.Root("enabled"), "Client Error", fmt.Sprintf("Unable to update proxy host, got err: %s", err))
 		return
@@ -298,12 +446,125 @@ func (r *ProxyHostResource) Update(ctx context.Context, req resource.UpdateReque
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 
+	if !data.Letsencrypt.IsNull() {
+		setLetsencryptCertificateId(ctx, resp.Private, data.CertificateId, &resp.Diagnostics)
+	} else if !requestedCertificateId.IsNull() {
+		setLetsencryptCertificateId(ctx, resp.Private, types.Int64Null(), &resp.Diagnostics)
+	}
+
+	// The certificate that was requested for the proxy host is no longer used.
+	if !requestedCertificateId.IsNull() && !requestedCertificateId.Equal(data.CertificateId) {
+		r.deleteCertificate(requestedCertificateId, &resp.Diagnostics)
+	}
+}
+
+func (r *ProxyHostResource) ConfigValidators(ctx context.Context) []resource.ConfigValidator {
+	return []resource.ConfigValidator{
+		wildcardRequiresDnsChallengeValidator{
+			domainNames:  path.Root("domain_names"),
+			dnsChallenge: path.Root("letsencrypt").AtName("dns_challenge"),
+		},
+		dnsChallengeRequiresProviderValidator{
+			dnsChallenge: path.Root("letsencrypt").AtName("dns_challenge"),
+			dnsProvider:  path.Root("letsencrypt").AtName("dns_provider"),
+		},
+	}
+}
+
+func (r *ProxyHostResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config *models.ProxyHostResource
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	validateCertificateSelection(config.CertificateSelection, config.CertificateId, &resp.Diagnostics)
+
+	if config.Letsencrypt.IsNull() || config.Letsencrypt.IsUnknown() {
+		return
+	}
+
+	if !config.CertificateId.IsNull() {
+		resp.Diagnostics.AddAttributeError(path.Root("certificate_id"), "Conflicting Certificate", "The certificate_id cannot be configured together with the letsencrypt block.")
+	}
+	if !config.CertificateSelection.IsNull() && !config.CertificateSelection.IsUnknown() && config.CertificateSelection.ValueString() != certificateSelectionManual {
+		resp.Diagnostics.AddAttributeError(path.Root("certificate_selection"), "Conflicting Certificate", "The certificate_selection must be manual when the letsencrypt block is configured.")
+	}
+
+	letsencrypt, diags := models.ProxyHostLetsencryptAs(ctx, config.Letsencrypt)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() || !letsencrypt.DnsChallenge.ValueBool() || letsencrypt.DnsProvider.IsNull() || letsencrypt.DnsProvider.IsUnknown() {
+		return
+	}
+
+	dnsProvider, ok := dnsProviders[letsencrypt.DnsProvider.ValueString()]
+	if !ok || letsencrypt.DnsCredentials.IsNull() || letsencrypt.DnsCredentials.IsUnknown() {
+		return
+	}
+
+	var credentials map[string]string
+	resp.Diagnostics.Append(letsencrypt.DnsCredentials.ElementsAs(ctx, &credentials, false)...)
+
+	dnsProvider.validateCredentials(path.Root("letsencrypt").AtName("dns_credentials"), credentials, &resp.Diagnostics)
+}
+
+func (r *ProxyHostResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan, config *models.ProxyHostResource
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !plan.Letsencrypt.IsNull() {
+		// The certificate is only kept while the domain names and the Let's Encrypt settings are unchanged. The
+		// requested certificate is planned, so a certificate_id changed outside of Terraform is set back.
+		plan.CertificateId = types.Int64Unknown()
+
+		if !req.State.Raw.IsNull() {
+			var state *models.ProxyHostResource
+
+			resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+			requestedCertificateId := letsencryptCertificateId(ctx, req.Private, &resp.Diagnostics)
+
+			if !resp.Diagnostics.HasError() && !requestedCertificateId.IsNull() && !state.Letsencrypt.IsNull() && !plan.Letsencrypt.IsUnknown() && state.DomainNames.Equal(plan.DomainNames) {
+				stateLetsencrypt, diags := models.ProxyHostLetsencryptAs(ctx, state.Letsencrypt)
+				resp.Diagnostics.Append(diags...)
+				planLetsencrypt, diags := models.ProxyHostLetsencryptAs(ctx, plan.Letsencrypt)
+				resp.Diagnostics.Append(diags...)
+
+				if !resp.Diagnostics.HasError() && stateLetsencrypt.RequestEquals(planLetsencrypt) {
+					plan.CertificateId = requestedCertificateId
+				}
+			}
+		}
+
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("certificate_id"), plan.CertificateId)...)
+		return
+	}
+
+	plan.CertificateId = resolveCertificateSelection(ctx, r.certificates, r.client, r.auth, plan.CertificateSelection, config.CertificateId, plan.DomainNames, &resp.Diagnostics)
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("certificate_id"), plan.CertificateId)...)
+
+	if plan.CertificateSelection.ValueString() == certificateSelectionManual {
+		checkCertificateCoverage(ctx, r.client, r.auth, r.strictCertificateCoverage, plan.CertificateId, plan.DomainNames, &resp.Diagnostics)
+	}
 }
 
 func (r *ProxyHostResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
-	var data *models.ProxyHost
+	var data *models.ProxyHostResource
 
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	requestedCertificateId := letsencryptCertificateId(ctx, req.Private, &resp.Diagnostics)
 
 	if resp.Diagnostics.HasError() {
 		return
@@ -319,6 +580,10 @@ func (r *ProxyHostResource) Delete(ctx context.Context, req resource.DeleteReque
 		resp.Diagnostics.AddError("Server Error", "Unable to delete proxy host.")
 		return
 	}
+
+	if !requestedCertificateId.IsNull() {
+		r.deleteCertificate(requestedCertificateId, &resp.Diagnostics)
+	}
 }
 
 func (r *ProxyHostResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
@@ -338,16 +603,16 @@ func (r *ProxyHostResource) ImportState(ctx context.Context, req resource.Import
 	resp.Diagnostics.Append(diags...)
 }
 
-func (r *ProxyHostResource) toggleHost(hostId int64, current bool, desired bool) error {
+func toggleProxyHost(client *nginxproxymanager.APIClient, auth context.Context, hostId int64, current bool, desired bool) error {
 	if desired && !current {
-		enableResponse, _, err := r.client.ProxyHostsAPI.EnableProxyHost(r.auth, hostId).Execute()
+		enableResponse, _, err := client.ProxyHostsAPI.EnableProxyHost(auth, hostId).Execute()
 		if err != nil {
 			return err
 		} else if !enableResponse {
 			return errors.New("unable to enable proxy host")
 		}
 	} else if !desired && current {
-		disableResponse, _, err := r.client.ProxyHostsAPI.DisableProxyHost(r.auth, hostId).Execute()
+		disableResponse, _, err := client.ProxyHostsAPI.DisableProxyHost(auth, hostId).Execute()
 		if err != nil {
 			return err
 		} else if !disableResponse {
@@ -357,3 +622,88 @@ func (r *ProxyHostResource) toggleHost(hostId int64, current bool, desired bool)
 
 	return nil
 }
+
+// createCertificate requests a Let's Encrypt certificate for the domain names of the proxy host, returning its Id.
+func (r *ProxyHostResource) createCertificate(ctx context.Context, auth context.Context, data *models.ProxyHostResource, timeout time.Duration, diags *diag.Diagnostics) types.Int64 {
+	letsencrypt, tmpDiags := models.ProxyHostLetsencryptAs(ctx, data.Letsencrypt)
+	diags.Append(tmpDiags...)
+
+	if diags.HasError() {
+		return types.Int64Unknown()
+	}
+
+	if letsencrypt.PreflightHttpCheck.ValueBool() && !letsencrypt.DnsChallenge.ValueBool() {
+		preflightHttpCheck(ctx, r.client, r.auth, data.DomainNames, diags)
+
+		if diags.HasError() {
+			return types.Int64Unknown()
+		}
+	}
+
+	request := letsencrypt.ToCreateRequest(ctx, data.DomainNames, diags)
+
+	if diags.HasError() {
+		return types.Int64Unknown()
+	}
+
+	certificate, err := createLetsencryptCertificate(ctx, r.client, auth, r.mutex, request, timeout)
+	if err != nil {
+		diags.AddAttributeError(path.Root("letsencrypt"), "Client Error", fmt.Sprintf("Unable to create certificate, got error: %s", err))
+		return types.Int64Unknown()
+	}
+
+	return types.Int64Value(certificate.GetId())
+}
+
+// deleteCertificate deletes a certificate that was requested for the proxy host, unless other hosts use it. Failing to
+// do so only results in a warning, as the proxy host itself is already up to date.
+func (r *ProxyHostResource) deleteCertificate(certificateId types.Int64, diags *diag.Diagnostics) {
+	refs, err := loadReferences(r.client, r.auth)
+	if err != nil {
+		diags.AddAttributeWarning(path.Root("letsencrypt"), "Certificate Not Deleted", fmt.Sprintf("Unable to check which hosts use certificate %d, it has to be deleted manually, got error: %s", certificateId.ValueInt64(), err))
+		return
+	}
+
+	if hosts := refs.Certificates[certificateId.ValueInt64()]; len(hosts) > 0 {
+		diags.AddAttributeWarning(path.Root("letsencrypt"), "Certificate Not Deleted", fmt.Sprintf("Certificate %d is used by the following hosts, it has to be deleted manually once they no longer use it:\n%s", certificateId.ValueInt64(), describeReferences(hosts)))
+		return
+	}
+
+	success, _, err := r.client.CertificatesAPI.DeleteCertificate(r.auth, certificateId.ValueInt64()).Execute()
+	if err == nil && !success {
+		err = errors.New("the server did not delete the certificate")
+	}
+
+	if err != nil {
+		diags.AddAttributeWarning(path.Root("letsencrypt"), "Certificate Not Deleted", fmt.Sprintf("Unable to delete certificate %d, it has to be deleted manually, got error: %s", certificateId.ValueInt64(), err))
+	}
+}
+
+// letsencryptCertificateId returns the Id of the certificate the letsencrypt block requested, or null when it did not
+// request one.
+func letsencryptCertificateId(ctx context.Context, private privateData, diags *diag.Diagnostics) types.Int64 {
+	value, tmpDiags := private.GetKey(ctx, letsencryptCertificateKey)
+	diags.Append(tmpDiags...)
+
+	if value == nil {
+		return types.Int64Null()
+	}
+
+	var certificateId int64
+	if err := json.Unmarshal(value, &certificateId); err != nil {
+		diags.AddError("Internal Error", fmt.Sprintf("Unable to read the requested certificate, got error: %s", err))
+		return types.Int64Null()
+	}
+
+	return types.Int64Value(certificateId)
+}
+
+// setLetsencryptCertificateId stores the Id of the certificate the letsencrypt block requested, removing it when null.
+func setLetsencryptCertificateId(ctx context.Context, private privateData, certificateId types.Int64, diags *diag.Diagnostics) {
+	var value []byte
+	if !certificateId.IsNull() && !certificateId.IsUnknown() {
+		value, _ = json.Marshal(certificateId.ValueInt64())
+	}
+
+	diags.Append(private.SetKey(ctx, letsencryptCertificateKey, value)...)
+}
@@ -0,0 +1,66 @@
+// Copyright (c) Sander Jochems
+// SPDX-License-Identifier: MIT
+
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+type testPrivateData map[string][]byte
+
+func (d testPrivateData) GetKey(_ context.Context, key string) ([]byte, diag.Diagnostics) {
+	return d[key], nil
+}
+
+func (d testPrivateData) SetKey(_ context.Context, key string, value []byte) diag.Diagnostics {
+	if len(value) == 0 {
+		delete(d, key)
+	} else {
+		d[key] = value
+	}
+
+	return nil
+}
+
+func TestLetsencryptCertificateId(t *testing.T) {
+	tests := map[string]struct {
+		certificateId types.Int64
+		expected      types.Int64
+	}{
+		"requested": {
+			certificateId: types.Int64Value(42),
+			expected:      types.Int64Value(42),
+		},
+		"null": {
+			certificateId: types.Int64Null(),
+			expected:      types.Int64Null(),
+		},
+		"unknown": {
+			certificateId: types.Int64Unknown(),
+			expected:      types.Int64Null(),
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			private := testPrivateData{letsencryptCertificateKey: []byte("7")}
+
+			var diags diag.Diagnostics
+			setLetsencryptCertificateId(ctx, private, test.certificateId, &diags)
+			certificateId := letsencryptCertificateId(ctx, private, &diags)
+
+			if diags.HasError() {
+				t.Fatalf("unexpected error: %v", diags)
+			}
+			if !certificateId.Equal(test.expected) {
+				t.Errorf("expected %s, got %s", test.expected, certificateId)
+			}
+		})
+	}
+}
@@ -27,6 +27,8 @@ import (
 
 var _ resource.Resource = &RedirectionHostResource{}
 var _ resource.ResourceWithImportState = &RedirectionHostResource{}
+var _ resource.ResourceWithModifyPlan = &RedirectionHostResource{}
+var _ resource.ResourceWithValidateConfig = &RedirectionHostResource{}
 
 func NewRedirectionHostResource() resource.Resource {
 	return &RedirectionHostResource{}
@@ -35,6 +37,10 @@ func NewRedirectionHostResource() resource.Resource {
 type RedirectionHostResource struct {
 	client *nginxproxymanager.APIClient
 	auth   context.Context
+
+	certificates *certificateList
+
+	strictCertificateCoverage bool
 }
 
 func (r *RedirectionHostResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -64,10 +70,12 @@ func (r *RedirectionHostResource) Schema(ctx context.Context, req resource.Schem
 				Computed:            true,
 			},
 			"owner_user_id": schema.Int64Attribute{
-				MarkdownDescription: "The Id of the user that owns the redirection host.",
+				MarkdownDescription: "The Id of the user that owns the redirection host. When set, the redirection host is created on behalf of this user. Changing the owner forces a new redirection host to be created, as Nginx Proxy Manager cannot reassign the owner of an existing redirection host.",
 				Computed:            true,
+				Optional:            true,
 				PlanModifiers: []planmodifier.Int64{
 					int64planmodifier.UseStateForUnknown(),
+					int64planmodifier.RequiresReplace(),
 				},
 			},
 			"domain_names": schema.SetAttribute{
@@ -110,9 +118,11 @@ func (r *RedirectionHostResource) Schema(ctx context.Context, req resource.Schem
 				Default:             booldefault.StaticBool(false),
 			},
 			"certificate_id": schema.Int64Attribute{
-				MarkdownDescription: "The Id of the certificate used by the redirection host.",
+				MarkdownDescription: "The Id of the certificate used by the redirection host. Can only be configured when `certificate_selection` is `manual`, otherwise the selected certificate is computed.",
+				Computed:            true,
 				Optional:            true,
 			},
+			"certificate_selection": certificateSelectionAttribute("domain_names"),
 			"ssl_forced": schema.BoolAttribute{
 				MarkdownDescription: "Whether SSL is forced for the redirection host.",
 				Computed:            true,
@@ -162,11 +172,13 @@ func (r *RedirectionHostResource) Configure(ctx context.Context, req resource.Co
 	if data := resourceConfigure(ctx, req, resp); data != nil {
 		r.client = data.Client
 		r.auth = data.Auth
+		r.certificates = &data.Certificates
+		r.strictCertificateCoverage = data.StrictCertificateCoverage
 	}
 }
 
 func (r *RedirectionHostResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
-	var data *models.RedirectionHost
+	var data *models.RedirectionHostResource
 
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
 
@@ -176,8 +188,14 @@ func (r *RedirectionHostResource) Create(ctx context.Context, req resource.Creat
 
 	hostEnabled := data.Enabled.ValueBool()
 
+	createAuth, err := ownerAuth(r.client, r.auth, data.OwnerUserId)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("owner_user_id"), "Client Error", fmt.Sprintf("Unable to log in as owner, got error: %s", err))
+		return
+	}
+
 	request := data.ToCreateRequest(ctx, &resp.Diagnostics)
-	redirectionHost, _, err := r.client.RedirectionHostsAPI.CreateRedirectionHost(r.auth).CreateRedirectionHostRequest(*request).Execute()
+	redirectionHost, _, err := r.client.RedirectionHostsAPI.CreateRedirectionHost(createAuth).CreateRedirectionHostRequest(*request).Execute()
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create redirection host, got error: %s", err))
 		return
@@ -187,7 +205,7 @@ func (r *RedirectionHostResource) Create(ctx context.Context, req resource.Creat
 
 	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), data.Id)...)
 
-	err = r.toggleHost(redirectionHost.GetId(), redirectionHost.GetEnabled(), hostEnabled)
+	err = toggleRedirectionHost(r.client, r.auth, redirectionHost.GetId(), redirectionHost.GetEnabled(), hostEnabled)
 	if err != nil {
 		resp.Diagnostics.AddAttributeError(path.Root("enabled"), "Client Error", fmt.Sprintf("Unable to update redirection host, got err: %s", err))
 		return
@@ -199,7 +217,7 @@ func (r *RedirectionHostResource) Create(ctx context.Context, req resource.Creat
 }
 
 func (r *RedirectionHostResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
-	var data *models.RedirectionHost
+	var data *models.RedirectionHostResource
 
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
 
@@ -224,7 +242,7 @@ func (r *RedirectionHostResource) Read(ctx context.Context, req resource.ReadReq
 }
 
 func (r *RedirectionHostResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	var data *models.RedirectionHost
+	var data *models.RedirectionHostResource
 
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
 
@@ -243,7 +261,7 @@ func (r *RedirectionHostResource) Update(ctx context.Context, req resource.Updat
 
 	data.Write(ctx, redirectionHost, &resp.Diagnostics)
 
-	err = r.toggleHost(redirectionHost.GetId(), redirectionHost.GetEnabled(), hostEnabled)
+	err = toggleRedirectionHost(r.client, r.auth, redirectionHost.GetId(), redirectionHost.GetEnabled(), hostEnabled)
 	if err != nil {
 		resp.Diagnostics.AddAttributeError(path.Root("enabled"), "Client Error", fmt.Sprintf("Unable to update redirection host, got err: %s", err))
 		return
@@ -255,8 +273,42 @@ func (r *RedirectionHostResource) Update(ctx context.Context, req resource.Updat
 
 }
 
+func (r *RedirectionHostResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config *models.RedirectionHostResource
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	validateCertificateSelection(config.CertificateSelection, config.CertificateId, &resp.Diagnostics)
+}
+
+func (r *RedirectionHostResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan, config *models.RedirectionHostResource
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	plan.CertificateId = resolveCertificateSelection(ctx, r.certificates, r.client, r.auth, plan.CertificateSelection, config.CertificateId, plan.DomainNames, &resp.Diagnostics)
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("certificate_id"), plan.CertificateId)...)
+
+	if plan.CertificateSelection.ValueString() == certificateSelectionManual {
+		checkCertificateCoverage(ctx, r.client, r.auth, r.strictCertificateCoverage, plan.CertificateId, plan.DomainNames, &resp.Diagnostics)
+	}
+}
+
 func (r *RedirectionHostResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
-	var data *models.RedirectionHost
+	var data *models.RedirectionHostResource
 
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
 
@@ -293,16 +345,16 @@ func (r *RedirectionHostResource) ImportState(ctx context.Context, req resource.
 	resp.Diagnostics.Append(diags...)
 }
 
-func (r *RedirectionHostResource) toggleHost(hostId int64, current bool, desired bool) error {
+func toggleRedirectionHost(client *nginxproxymanager.APIClient, auth context.Context, hostId int64, current bool, desired bool) error {
 	if desired && !current {
-		enableResponse, _, err := r.client.RedirectionHostsAPI.EnableRedirectionHost(r.auth, hostId).Execute()
+		enableResponse, _, err := client.RedirectionHostsAPI.EnableRedirectionHost(auth, hostId).Execute()
 		if err != nil {
 			return err
 		} else if !enableResponse {
 			return errors.New("unable to enable redirection host")
 		}
 	} else if !desired && current {
-		disableResponse, _, err := r.client.RedirectionHostsAPI.DisableRedirectionHost(r.auth, hostId).Execute()
+		disableResponse, _, err := client.RedirectionHostsAPI.DisableRedirectionHost(auth, hostId).Execute()
 		if err != nil {
 			return err
 		} else if !disableResponse {
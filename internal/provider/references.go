@@ -0,0 +1,198 @@
+// Copyright (c) Sander Jochems
+// SPDX-License-Identifier: MIT
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/sander0542/nginxproxymanager-go"
+	"github.com/sander0542/terraform-provider-nginxproxymanager/internal/provider/models"
+	"sort"
+	"strings"
+)
+
+const (
+	hostTypeProxyHost       = "proxy_host"
+	hostTypeRedirectionHost = "redirection_host"
+	hostTypeDeadHost        = "dead_host"
+	hostTypeStream          = "stream"
+)
+
+// references contains the hosts referencing each certificate and access list, by their Id.
+type references struct {
+	Certificates map[int64][]models.HostReference
+	AccessLists  map[int64][]models.HostReference
+}
+
+// loadReferences reads all hosts and streams, and collects the certificates and access lists they reference.
+func loadReferences(client *nginxproxymanager.APIClient, auth context.Context) (*references, error) {
+	refs := &references{
+		Certificates: map[int64][]models.HostReference{},
+		AccessLists:  map[int64][]models.HostReference{},
+	}
+
+	proxyHosts, _, err := client.ProxyHostsAPI.GetProxyHosts(auth).Execute()
+	if err != nil {
+		return nil, fmt.Errorf("unable to read proxy hosts: %w", err)
+	}
+	for _, host := range proxyHosts {
+		reference := models.HostReference{Type: hostTypeProxyHost, Id: host.GetId(), Name: strings.Join(host.GetDomainNames(), ", ")}
+		refs.addCertificate(host.GetCertificateId(), reference)
+		if host.GetAccessListId() != 0 {
+			refs.AccessLists[host.GetAccessListId()] = append(refs.AccessLists[host.GetAccessListId()], reference)
+		}
+	}
+
+	redirectionHosts, _, err := client.RedirectionHostsAPI.GetRedirectionHosts(auth).Execute()
+	if err != nil {
+		return nil, fmt.Errorf("unable to read redirection hosts: %w", err)
+	}
+	for _, host := range redirectionHosts {
+		refs.addCertificate(host.GetCertificateId(), models.HostReference{Type: hostTypeRedirectionHost, Id: host.GetId(), Name: strings.Join(host.GetDomainNames(), ", ")})
+	}
+
+	deadHosts, _, err := client.Class404HostsAPI.GetDeadHosts(auth).Execute()
+	if err != nil {
+		return nil, fmt.Errorf("unable to read dead hosts: %w", err)
+	}
+	for _, host := range deadHosts {
+		refs.addCertificate(host.GetCertificateId(), models.HostReference{Type: hostTypeDeadHost, Id: host.GetId(), Name: strings.Join(host.GetDomainNames(), ", ")})
+	}
+
+	streams, _, err := client.StreamsAPI.GetStreams(auth).Execute()
+	if err != nil {
+		return nil, fmt.Errorf("unable to read streams: %w", err)
+	}
+	for _, stream := range streams {
+		refs.addCertificate(stream.GetCertificateId(), models.HostReference{Type: hostTypeStream, Id: stream.GetId(), Name: fmt.Sprintf("port %d", stream.GetIncomingPort())})
+	}
+
+	return refs, nil
+}
+
+func (r *references) addCertificate(certificateId nginxproxymanager.GetProxyHosts200ResponseInnerCertificateId, reference models.HostReference) {
+	if certificateId.Int64 == nil || *certificateId.Int64 == 0 {
+		return
+	}
+
+	r.Certificates[*certificateId.Int64] = append(r.Certificates[*certificateId.Int64], reference)
+}
+
+// describeReferences returns a sorted list of the hosts, one per line.
+func describeReferences(hosts []models.HostReference) string {
+	lines := make([]string, 0, len(hosts))
+	for _, host := range hosts {
+		lines = append(lines, "- "+host.String())
+	}
+	sort.Strings(lines)
+
+	return strings.Join(lines, "\n")
+}
+
+// detachCertificate removes the certificate from the host. SSL is no longer forced on the host, and HSTS and HTTP/2
+// are disabled, as they are not possible without a certificate.
+func detachCertificate(client *nginxproxymanager.APIClient, auth context.Context, host models.HostReference) error {
+	var err error
+	switch host.Type {
+	case hostTypeProxyHost:
+		_, _, err = client.ProxyHostsAPI.UpdateProxyHost(auth, host.Id).UpdateProxyHostRequest(*detachProxyHostCertificateRequest()).Execute()
+	case hostTypeRedirectionHost:
+		_, _, err = client.RedirectionHostsAPI.UpdateRedirectionHost(auth, host.Id).UpdateRedirectionHostRequest(*detachRedirectionHostCertificateRequest()).Execute()
+	case hostTypeDeadHost:
+		_, _, err = client.Class404HostsAPI.UpdateDeadHost(auth, host.Id).UpdateDeadHostRequest(*detachDeadHostCertificateRequest()).Execute()
+	case hostTypeStream:
+		_, _, err = client.StreamsAPI.UpdateStream(auth, host.Id).UpdateStreamRequest(*detachStreamCertificateRequest()).Execute()
+	default:
+		err = fmt.Errorf("unsupported host type %q", host.Type)
+	}
+
+	return err
+}
+
+// noCertificateId returns the certificate Id that removes the certificate from a host.
+func noCertificateId() nginxproxymanager.GetProxyHosts200ResponseInnerCertificateId {
+	var noCertificate int64
+
+	return nginxproxymanager.GetProxyHosts200ResponseInnerCertificateId{Int64: &noCertificate}
+}
+
+// The detach requests only set the fields that change, so the other settings of the host are kept.
+
+func detachProxyHostCertificateRequest() *nginxproxymanager.UpdateProxyHostRequest {
+	request := nginxproxymanager.NewUpdateProxyHostRequest()
+	request.SetCertificateId(noCertificateId())
+	request.SetSslForced(false)
+	request.SetHstsEnabled(false)
+	request.SetHttp2Support(false)
+
+	return request
+}
+
+func detachRedirectionHostCertificateRequest() *nginxproxymanager.UpdateRedirectionHostRequest {
+	request := nginxproxymanager.NewUpdateRedirectionHostRequest()
+	request.SetCertificateId(noCertificateId())
+	request.SetSslForced(false)
+	request.SetHstsEnabled(false)
+	request.SetHttp2Support(false)
+
+	return request
+}
+
+func detachDeadHostCertificateRequest() *nginxproxymanager.UpdateDeadHostRequest {
+	request := nginxproxymanager.NewUpdateDeadHostRequest()
+	request.SetCertificateId(noCertificateId())
+	request.SetSslForced(false)
+	request.SetHstsEnabled(false)
+	request.SetHttp2Support(false)
+
+	return request
+}
+
+func detachStreamCertificateRequest() *nginxproxymanager.UpdateStreamRequest {
+	request := nginxproxymanager.NewUpdateStreamRequest()
+	request.SetCertificateId(noCertificateId())
+
+	return request
+}
+
+// detachAccessList removes the access list from the host.
+func detachAccessList(client *nginxproxymanager.APIClient, auth context.Context, host models.HostReference) error {
+	if host.Type != hostTypeProxyHost {
+		return fmt.Errorf("unsupported host type %q", host.Type)
+	}
+
+	_, _, err := client.ProxyHostsAPI.UpdateProxyHost(auth, host.Id).UpdateProxyHostRequest(*detachProxyHostAccessListRequest()).Execute()
+
+	return err
+}
+
+func detachProxyHostAccessListRequest() *nginxproxymanager.UpdateProxyHostRequest {
+	request := nginxproxymanager.NewUpdateProxyHostRequest()
+	request.SetAccessListId(0)
+
+	return request
+}
+
+// releaseReferences adds an error listing the hosts that still reference the object, or detaches the object from
+// these hosts when forceDetach is set.
+func releaseReferences(hosts []models.HostReference, summary string, object string, forceDetach bool, detach func(models.HostReference) error, diags *diag.Diagnostics) {
+	if len(hosts) == 0 {
+		return
+	}
+
+	if !forceDetach {
+		diags.AddError(
+			summary,
+			fmt.Sprintf("The %s is used by the following hosts:\n%s\n\nRemove the %s from these hosts, or set force_detach to remove it from them before deleting the %s. When the %s is replaced, set create_before_destroy in its lifecycle block, so the hosts are moved to the new %s before this one is deleted.", object, describeReferences(hosts), object, object, object, object),
+		)
+		return
+	}
+
+	for _, host := range hosts {
+		if err := detach(host); err != nil {
+			diags.AddError("Client Error", fmt.Sprintf("Unable to remove the %s from %s, got error: %s", object, host, err))
+		}
+	}
+}
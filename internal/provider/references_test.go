@@ -0,0 +1,76 @@
+// Copyright (c) Sander Jochems
+// SPDX-License-Identifier: MIT
+
+package provider
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestDetachRequests(t *testing.T) {
+	tests := map[string]struct {
+		request  interface{}
+		expected map[string]interface{}
+	}{
+		"proxy-host-certificate": {
+			request: detachProxyHostCertificateRequest(),
+			expected: map[string]interface{}{
+				"certificate_id": float64(0),
+				"ssl_forced":     false,
+				"hsts_enabled":   false,
+				"http2_support":  false,
+			},
+		},
+		"redirection-host-certificate": {
+			request: detachRedirectionHostCertificateRequest(),
+			expected: map[string]interface{}{
+				"certificate_id": float64(0),
+				"ssl_forced":     false,
+				"hsts_enabled":   false,
+				"http2_support":  false,
+			},
+		},
+		"dead-host-certificate": {
+			request: detachDeadHostCertificateRequest(),
+			expected: map[string]interface{}{
+				"certificate_id": float64(0),
+				"ssl_forced":     false,
+				"hsts_enabled":   false,
+				"http2_support":  false,
+			},
+		},
+		"stream-certificate": {
+			request: detachStreamCertificateRequest(),
+			expected: map[string]interface{}{
+				"certificate_id": float64(0),
+			},
+		},
+		"proxy-host-access-list": {
+			request: detachProxyHostAccessListRequest(),
+			expected: map[string]interface{}{
+				"access_list_id": float64(0),
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			body, err := json.Marshal(test.request)
+			if err != nil {
+				t.Fatalf("unable to marshal request: %s", err)
+			}
+
+			// Fields that are not set must be left out, so Nginx Proxy Manager keeps the other settings of the host.
+			var actual map[string]interface{}
+			if err := json.Unmarshal(body, &actual); err != nil {
+				t.Fatalf("unable to unmarshal request: %s", err)
+			}
+
+			if !reflect.DeepEqual(actual, test.expected) {
+				t.Errorf("expected %v, got %v", test.expected, actual)
+			}
+		})
+	}
+}
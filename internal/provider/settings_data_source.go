@@ -10,6 +10,7 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/sander0542/nginxproxymanager-go"
 )
 
@@ -48,6 +49,27 @@ func (d *SettingsDataSource) Schema(ctx context.Context, req datasource.SchemaRe
 						MarkdownDescription: "HTML Content.",
 						Computed:            true,
 					},
+					"html_sha256": schema.StringAttribute{
+						MarkdownDescription: "The SHA-256 checksum of the HTML Content.",
+						Computed:            true,
+					},
+				},
+			},
+			"settings": schema.MapNestedAttribute{
+				MarkdownDescription: "Settings without a dedicated attribute, keyed by the Id of the setting.",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"value": schema.StringAttribute{
+							MarkdownDescription: "The value of the setting.",
+							Computed:            true,
+						},
+						"meta": schema.MapAttribute{
+							MarkdownDescription: "Additional options of the setting.",
+							ElementType:         types.StringType,
+							Computed:            true,
+						},
+					},
 				},
 			},
 		},
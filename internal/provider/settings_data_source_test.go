@@ -31,11 +31,17 @@ func TestAccSettingsDataSource(t *testing.T) {
 						"data.nginxproxymanager_settings.test",
 						tfjsonpath.New("default_site"),
 						knownvalue.ObjectExact(map[string]knownvalue.Check{
-							"page":     knownvalue.StringExact("congratulations"),
-							"html":     knownvalue.Null(),
-							"redirect": knownvalue.Null(),
+							"page":        knownvalue.StringExact("congratulations"),
+							"html":        knownvalue.Null(),
+							"redirect":    knownvalue.Null(),
+							"html_sha256": knownvalue.Null(),
 						}),
 					),
+					statecheck.ExpectKnownValue(
+						"data.nginxproxymanager_settings.test",
+						tfjsonpath.New("settings"),
+						knownvalue.MapExact(map[string]knownvalue.Check{}),
+					),
 				},
 			},
 		},
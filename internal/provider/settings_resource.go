@@ -4,21 +4,31 @@
 package provider
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/objectplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/sander0542/nginxproxymanager-go"
 	"github.com/sander0542/terraform-provider-nginxproxymanager/internal/provider/models"
+	"net/http"
+	"net/url"
 )
 
 var _ resource.Resource = &SettingsResource{}
+var _ resource.ResourceWithImportState = &SettingsResource{}
+var _ resource.ResourceWithValidateConfig = &SettingsResource{}
+var _ resource.ResourceWithModifyPlan = &SettingsResource{}
 
 func NewSettingsResource() resource.Resource {
 	return &SettingsResource{}
@@ -29,9 +39,23 @@ type SettingsResource struct {
 	auth   context.Context
 }
 
+// updateRequest updates a setting through the SDK, or with a plain JSON body for the settings without a dedicated
+// attribute and when restoring the original settings, as the SDK only knows the meta keys of the default site.
 type updateRequest struct {
 	Id      string
+	Path    path.Path
 	Request *nginxproxymanager.UpdateSettingRequest
+	Body    *models.SettingRequest
+}
+
+type privateSetting struct {
+	Value *string                `json:"value"`
+	Meta  map[string]interface{} `json:"meta"`
+}
+
+type privateData interface {
+	GetKey(ctx context.Context, key string) ([]byte, diag.Diagnostics)
+	SetKey(ctx context.Context, key string, value []byte) diag.Diagnostics
 }
 
 func (r *SettingsResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -58,17 +82,54 @@ func (r *SettingsResource) Schema(ctx context.Context, req resource.SchemaReques
 						},
 					},
 					"redirect": schema.StringAttribute{
-						MarkdownDescription: "Redirect to.",
+						MarkdownDescription: "Redirect to. Required when `page` is `redirect`.",
 						Optional:            true,
-						Computed:            true,
 					},
 					"html": schema.StringAttribute{
-						MarkdownDescription: "HTML Content.",
+						MarkdownDescription: "HTML Content. Either `html` or `html_file` is required when `page` is `html`.",
+						Optional:            true,
+						Validators: []validator.String{
+							stringvalidator.ConflictsWith(path.MatchRelative().AtParent().AtName("html_file")),
+						},
+					},
+					"html_file": schema.StringAttribute{
+						MarkdownDescription: "Path to a file with the HTML Content. Only the checksum of the content is stored in the state.",
 						Optional:            true,
+					},
+					"html_sha256": schema.StringAttribute{
+						MarkdownDescription: "The SHA-256 checksum of the HTML Content.",
 						Computed:            true,
 					},
 				},
 			},
+			"settings": schema.MapNestedAttribute{
+				MarkdownDescription: "Settings without a dedicated attribute, keyed by the Id of the setting. Only the settings in this map are managed.",
+				Computed:            true,
+				Optional:            true,
+				PlanModifiers: []planmodifier.Map{
+					mapplanmodifier.UseStateForUnknown(),
+				},
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"value": schema.StringAttribute{
+							MarkdownDescription: "The value of the setting.",
+							Required:            true,
+						},
+						"meta": schema.MapAttribute{
+							MarkdownDescription: "Additional options of the setting.",
+							ElementType:         types.StringType,
+							Optional:            true,
+							Computed:            true,
+						},
+					},
+				},
+			},
+			"reset_on_destroy": schema.BoolAttribute{
+				MarkdownDescription: "Whether to restore the managed settings to the values they had before they were managed by Terraform when the resource is destroyed. Defaults to `false`.",
+				Computed:            true,
+				Optional:            true,
+				Default:             booldefault.StaticBool(false),
+			},
 		},
 	}
 }
@@ -80,8 +141,71 @@ func (r *SettingsResource) Configure(ctx context.Context, req resource.Configure
 	}
 }
 
+func (r *SettingsResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data *models.SettingsResource
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() || data.DefaultSite.IsNull() || data.DefaultSite.IsUnknown() {
+		return
+	}
+
+	defaultSite, diags := models.SettingDefaultSiteResourceAs(ctx, data.DefaultSite)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() || defaultSite.Page.IsUnknown() {
+		return
+	}
+
+	page := defaultSite.Page.ValueString()
+	attributePath := path.Root("default_site")
+
+	if page == "redirect" && defaultSite.Redirect.IsNull() {
+		resp.Diagnostics.AddAttributeError(attributePath.AtName("redirect"), "Missing Attribute", "The attribute `redirect` is required when `page` is \"redirect\".")
+	}
+	if page != "redirect" && !defaultSite.Redirect.IsNull() {
+		resp.Diagnostics.AddAttributeError(attributePath.AtName("redirect"), "Invalid Attribute", "The attribute `redirect` can only be set when `page` is \"redirect\".")
+	}
+	if page == "html" && defaultSite.Html.IsNull() && defaultSite.HtmlFile.IsNull() {
+		resp.Diagnostics.AddAttributeError(attributePath.AtName("html"), "Missing Attribute", "One of the attributes `html` or `html_file` is required when `page` is \"html\".")
+	}
+	if page != "html" && !defaultSite.Html.IsNull() {
+		resp.Diagnostics.AddAttributeError(attributePath.AtName("html"), "Invalid Attribute", "The attribute `html` can only be set when `page` is \"html\".")
+	}
+	if page != "html" && !defaultSite.HtmlFile.IsNull() {
+		resp.Diagnostics.AddAttributeError(attributePath.AtName("html_file"), "Invalid Attribute", "The attribute `html_file` can only be set when `page` is \"html\".")
+	}
+}
+
+func (r *SettingsResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var config, plan *models.SettingsResource
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+
+	if resp.Diagnostics.HasError() || config.DefaultSite.IsNull() || plan.DefaultSite.IsUnknown() {
+		return
+	}
+
+	defaultSite, diags := models.SettingDefaultSiteResourceAs(ctx, plan.DefaultSite)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Compare the checksum of the configured content with the checksum of the content in Nginx Proxy Manager, so changes
+	// made outside of Terraform are detected, even when the content is loaded from a file.
+	htmlSha256 := models.HtmlSha256(defaultSite.GetHtml(path.Root("default_site"), &resp.Diagnostics))
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("default_site").AtName("html_sha256"), htmlSha256)...)
+}
+
 func (r *SettingsResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
-	var data *models.Settings
+	var data *models.SettingsResource
 
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
 
@@ -89,6 +213,12 @@ func (r *SettingsResource) Create(ctx context.Context, req resource.CreateReques
 		return
 	}
 
+	r.rememberSettings(ctx, resp.Private, &resp.Diagnostics)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	r.updateSettings(ctx, data, &resp.Diagnostics)
 
 	if resp.Diagnostics.HasError() {
@@ -107,7 +237,7 @@ func (r *SettingsResource) Create(ctx context.Context, req resource.CreateReques
 }
 
 func (r *SettingsResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
-	var data *models.Settings
+	var data *models.SettingsResource
 
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
 
@@ -127,7 +257,7 @@ func (r *SettingsResource) Read(ctx context.Context, req resource.ReadRequest, r
 }
 
 func (r *SettingsResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	var data *models.Settings
+	var data *models.SettingsResource
 
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
 
@@ -135,6 +265,12 @@ func (r *SettingsResource) Update(ctx context.Context, req resource.UpdateReques
 		return
 	}
 
+	r.rememberSettings(ctx, resp.Private, &resp.Diagnostics)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	r.updateSettings(ctx, data, &resp.Diagnostics)
 
 	if resp.Diagnostics.HasError() {
@@ -153,19 +289,138 @@ func (r *SettingsResource) Update(ctx context.Context, req resource.UpdateReques
 }
 
 func (r *SettingsResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
-	resp.Diagnostics.AddWarning("Settings not changed", "The settings resource has been removed, but the settings have not been changed.")
+	var data *models.SettingsResource
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !data.ResetOnDestroy.ValueBool() {
+		resp.Diagnostics.AddWarning("Settings not changed", "The settings resource has been removed, but the settings have not been changed. Set `reset_on_destroy` to restore the settings when the resource is destroyed.")
+		return
+	}
+
+	originals := map[string]privateSetting{}
+	privateOriginals, diags := req.Private.GetKey(ctx, "original_settings")
+	resp.Diagnostics.Append(diags...)
+	if privateOriginals != nil {
+		if err := json.Unmarshal(privateOriginals, &originals); err != nil {
+			resp.Diagnostics.AddError("Internal Error", fmt.Sprintf("Unable to read the original settings, got error: %s", err))
+			return
+		}
+	}
+
+	var requests []updateRequest
+
+	if !data.DefaultSite.IsNull() {
+		original, ok := originals["default-site"]
+		if !ok {
+			page := "congratulations"
+			original = privateSetting{Value: &page}
+		}
+
+		requests = append(requests, updateRequest{
+			Id:   "default-site",
+			Path: path.Root("default_site"),
+			Body: original.toRequest(),
+		})
+	}
+
+	for id := range data.Settings.Elements() {
+		original, ok := originals[id]
+		if !ok {
+			resp.Diagnostics.AddAttributeWarning(path.Root("settings").AtMapKey(id), "Setting not reset", "The original value of the setting is unknown, so the setting has not been changed.")
+			continue
+		}
+
+		requests = append(requests, updateRequest{
+			Id:   id,
+			Path: path.Root("settings").AtMapKey(id),
+			Body: original.toRequest(),
+		})
+	}
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	r.executeRequests(ctx, requests, &resp.Diagnostics)
+}
+
+func (r *SettingsResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	r.rememberSettings(ctx, resp.Private, &resp.Diagnostics)
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("reset_on_destroy"), false)...)
 }
 
-func (r *SettingsResource) updateSettings(ctx context.Context, data *models.Settings, diags *diag.Diagnostics) {
-	requests := map[string]updateRequest{}
+// rememberSettings stores the current value of every setting that has not been stored before, so it can be restored
+// when the resource is destroyed.
+func (r *SettingsResource) rememberSettings(ctx context.Context, private privateData, diags *diag.Diagnostics) {
+	originals := map[string]privateSetting{}
 
-	if !data.DefaultSite.IsUnknown() {
-		defaultSite, tmpDiags := models.SettingDefaultSiteAs(ctx, data.DefaultSite)
+	privateOriginals, tmpDiags := private.GetKey(ctx, "original_settings")
+	diags.Append(tmpDiags...)
+	if privateOriginals != nil {
+		_ = json.Unmarshal(privateOriginals, &originals)
+	}
+
+	settings, _, err := r.client.SettingsAPI.GetSettings(r.auth).Execute()
+	if err != nil {
+		diags.AddError("Client Error", fmt.Sprintf("Unable to read settings, got error: %s", err))
+		return
+	}
+
+	for _, setting := range settings {
+		if _, ok := originals[setting.GetId()]; !ok {
+			originals[setting.GetId()] = privateSetting{
+				Value: setting.GetValue().String,
+				Meta:  setting.GetMeta(),
+			}
+		}
+	}
+
+	privateOriginals, err = json.Marshal(originals)
+	if err != nil {
+		diags.AddError("Internal Error", fmt.Sprintf("Unable to store the original settings, got error: %s", err))
+		return
+	}
+
+	diags.Append(private.SetKey(ctx, "original_settings", privateOriginals)...)
+}
+
+func (r *SettingsResource) updateSettings(ctx context.Context, data *models.SettingsResource, diags *diag.Diagnostics) {
+	var requests []updateRequest
+
+	if !data.DefaultSite.IsUnknown() && !data.DefaultSite.IsNull() {
+		defaultSite, tmpDiags := models.SettingDefaultSiteResourceAs(ctx, data.DefaultSite)
 		diags.Append(tmpDiags...)
 
-		requests["default_site"] = updateRequest{
+		requests = append(requests, updateRequest{
 			Id:      "default-site",
-			Request: defaultSite.ToRequest(ctx, diags),
+			Path:    path.Root("default_site"),
+			Request: defaultSite.ToRequest(ctx, path.Root("default_site"), diags),
+		})
+	}
+
+	if !data.Settings.IsUnknown() && !data.Settings.IsNull() {
+		settings, tmpDiags := models.SettingsElementsAs(ctx, data.Settings)
+		diags.Append(tmpDiags...)
+
+		for id, setting := range settings {
+			attributePath := path.Root("settings").AtMapKey(id)
+
+			if models.TypedSettingIds[id] {
+				diags.AddAttributeError(attributePath, "Invalid Setting", fmt.Sprintf("The setting %q must be managed using its dedicated attribute.", id))
+				continue
+			}
+
+			requests = append(requests, updateRequest{
+				Id:   id,
+				Path: attributePath,
+				Body: setting.ToRequest(),
+			})
 		}
 	}
 
@@ -173,10 +428,38 @@ func (r *SettingsResource) updateSettings(ctx context.Context, data *models.Sett
 		return
 	}
 
-	for attributeName, request := range requests {
-		_, _, err := r.client.SettingsAPI.UpdateSetting(r.auth, request.Id).UpdateSettingRequest(*request.Request).Execute()
+	r.executeRequests(ctx, requests, diags)
+}
+
+func (r *SettingsResource) executeRequests(ctx context.Context, requests []updateRequest, diags *diag.Diagnostics) {
+	for _, request := range requests {
+		var err error
+		if request.Body != nil {
+			err = r.updateSetting(ctx, request.Id, request.Body)
+		} else {
+			_, _, err = r.client.SettingsAPI.UpdateSetting(r.auth, request.Id).UpdateSettingRequest(*request.Request).Execute()
+		}
 		if err != nil {
-			diags.AddAttributeError(path.Root(attributeName), "Client Error", fmt.Sprintf("Unable to update setting, got error: %s", err))
+			diags.AddAttributeError(request.Path, "Client Error", fmt.Sprintf("Unable to update setting, got error: %s", err))
 		}
 	}
 }
+
+func (r *SettingsResource) updateSetting(ctx context.Context, id string, body *models.SettingRequest) error {
+	content, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+
+	_, err = apiRequest(ctx, r.client, r.auth, http.MethodPut, "/settings/"+url.PathEscape(id), bytes.NewReader(content), "application/json")
+
+	return err
+}
+
+// toRequest restores the original setting, passing the meta values through as they were read.
+func (s privateSetting) toRequest() *models.SettingRequest {
+	return &models.SettingRequest{
+		Value: s.Value,
+		Meta:  s.Meta,
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
@@ -14,6 +15,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/sander0542/nginxproxymanager-go"
 	"github.com/sander0542/terraform-provider-nginxproxymanager/internal/provider/models"
@@ -22,6 +24,8 @@ import (
 
 var _ resource.Resource = &StreamResource{}
 var _ resource.ResourceWithImportState = &StreamResource{}
+var _ resource.ResourceWithModifyPlan = &StreamResource{}
+var _ resource.ResourceWithValidateConfig = &StreamResource{}
 
 func NewStreamResource() resource.Resource {
 	return &StreamResource{}
@@ -30,6 +34,8 @@ func NewStreamResource() resource.Resource {
 type StreamResource struct {
 	client *nginxproxymanager.APIClient
 	auth   context.Context
+
+	certificates *certificateList
 }
 
 func (r *StreamResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -59,10 +65,12 @@ func (r *StreamResource) Schema(ctx context.Context, req resource.SchemaRequest,
 				Computed:            true,
 			},
 			"owner_user_id": schema.Int64Attribute{
-				MarkdownDescription: "The Id of the user that owns the stream.",
+				MarkdownDescription: "The Id of the user that owns the stream. When set, the stream is created on behalf of this user. Changing the owner forces a new stream to be created, as Nginx Proxy Manager cannot reassign the owner of an existing stream.",
 				Computed:            true,
+				Optional:            true,
 				PlanModifiers: []planmodifier.Int64{
 					int64planmodifier.UseStateForUnknown(),
+					int64planmodifier.RequiresReplace(),
 				},
 			},
 			"incoming_port": schema.Int64Attribute{
@@ -90,8 +98,18 @@ func (r *StreamResource) Schema(ctx context.Context, req resource.SchemaRequest,
 				Default:             booldefault.StaticBool(false),
 			},
 			"certificate_id": schema.Int64Attribute{
-				MarkdownDescription: "The Id of the certificate used by the stream.",
+				MarkdownDescription: "The Id of the certificate used by the stream. Can only be configured when `certificate_selection` is `manual`, otherwise the selected certificate is computed.",
+				Computed:            true,
+				Optional:            true,
+			},
+			"certificate_selection": certificateSelectionAttribute("certificate_domain_names"),
+			"certificate_domain_names": schema.SetAttribute{
+				MarkdownDescription: "The domain names the certificate of the stream must cover. Required when `certificate_selection` is not `manual`.",
 				Optional:            true,
+				ElementType:         types.StringType,
+				Validators: []validator.Set{
+					setvalidator.SizeAtLeast(1),
+				},
 			},
 			"enabled": schema.BoolAttribute{
 				MarkdownDescription: "Whether the stream is enabled.",
@@ -112,11 +130,12 @@ func (r *StreamResource) Configure(ctx context.Context, req resource.ConfigureRe
 	if data := resourceConfigure(ctx, req, resp); data != nil {
 		r.client = data.Client
 		r.auth = data.Auth
+		r.certificates = &data.Certificates
 	}
 }
 
 func (r *StreamResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
-	var data *models.Stream
+	var data *models.StreamResource
 
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
 
@@ -126,8 +145,14 @@ func (r *StreamResource) Create(ctx context.Context, req resource.CreateRequest,
 
 	streamEnabled := data.Enabled.ValueBool()
 
+	createAuth, err := ownerAuth(r.client, r.auth, data.OwnerUserId)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("owner_user_id"), "Client Error", fmt.Sprintf("Unable to log in as owner, got error: %s", err))
+		return
+	}
+
 	request := data.ToCreateRequest(ctx, &resp.Diagnostics)
-	stream, _, err := r.client.StreamsAPI.CreateStream(r.auth).CreateStreamRequest(*request).Execute()
+	stream, _, err := r.client.StreamsAPI.CreateStream(createAuth).CreateStreamRequest(*request).Execute()
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create stream, got error: %s", err))
 		return
@@ -137,7 +162,7 @@ func (r *StreamResource) Create(ctx context.Context, req resource.CreateRequest,
 
 	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), data.Id)...)
 
-	err = r.toggleStream(stream.GetId(), stream.GetEnabled(), streamEnabled)
+	err = toggleStream(r.client, r.auth, stream.GetId(), stream.GetEnabled(), streamEnabled)
 	if err != nil {
 		resp.Diagnostics.AddAttributeError(path.Root("enabled"), "Client Error", fmt.Sprintf("Unable to update stream, got err: %s", err))
 		return
@@ -149,7 +174,7 @@ func (r *StreamResource) Create(ctx context.Context, req resource.CreateRequest,
 }
 
 func (r *StreamResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
-	var data *models.Stream
+	var data *models.StreamResource
 
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
 
@@ -174,7 +199,7 @@ func (r *StreamResource) Read(ctx context.Context, req resource.ReadRequest, res
 }
 
 func (r *StreamResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	var data *models.Stream
+	var data *models.StreamResource
 
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
 
@@ -193,7 +218,7 @@ func (r *StreamResource) Update(ctx context.Context, req resource.UpdateRequest,
 
 	data.Write(ctx, stream, &resp.Diagnostics)
 
-	err = r.toggleStream(stream.GetId(), stream.GetEnabled(), streamEnabled)
+	err = toggleStream(r.client, r.auth, stream.GetId(), stream.GetEnabled(), streamEnabled)
 	if err != nil {
 		resp.Diagnostics.AddAttributeError(path.Root("enabled"), "Client Error", fmt.Sprintf("Unable to update stream, got err: %s", err))
 		return
@@ -205,8 +230,42 @@ func (r *StreamResource) Update(ctx context.Context, req resource.UpdateRequest,
 
 }
 
+func (r *StreamResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config *models.StreamResource
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	validateCertificateSelection(config.CertificateSelection, config.CertificateId, &resp.Diagnostics)
+
+	if !config.CertificateSelection.IsNull() && !config.CertificateSelection.IsUnknown() && config.CertificateSelection.ValueString() != certificateSelectionManual && config.CertificateDomainNames.IsNull() {
+		resp.Diagnostics.AddAttributeError(path.Root("certificate_domain_names"), "Missing Certificate Domain Names", fmt.Sprintf("The certificate_domain_names are required when certificate_selection is %q.", config.CertificateSelection.ValueString()))
+	}
+}
+
+func (r *StreamResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan, config *models.StreamResource
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	plan.CertificateId = resolveCertificateSelection(ctx, r.certificates, r.client, r.auth, plan.CertificateSelection, config.CertificateId, plan.CertificateDomainNames, &resp.Diagnostics)
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("certificate_id"), plan.CertificateId)...)
+}
+
 func (r *StreamResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
-	var data *models.Stream
+	var data *models.StreamResource
 
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
 
@@ -243,16 +302,16 @@ func (r *StreamResource) ImportState(ctx context.Context, req resource.ImportSta
 	resp.Diagnostics.Append(diags...)
 }
 
-func (r *StreamResource) toggleStream(streamId int64, current bool, desired bool) error {
+func toggleStream(client *nginxproxymanager.APIClient, auth context.Context, streamId int64, current bool, desired bool) error {
 	if desired && !current {
-		enableResponse, _, err := r.client.StreamsAPI.EnableStream(r.auth, streamId).Execute()
+		enableResponse, _, err := client.StreamsAPI.EnableStream(auth, streamId).Execute()
 		if err != nil {
 			return err
 		} else if !enableResponse {
 			return errors.New("unable to enable stream")
 		}
 	} else if !desired && current {
-		disableResponse, _, err := r.client.StreamsAPI.DisableStream(r.auth, streamId).Execute()
+		disableResponse, _, err := client.StreamsAPI.DisableStream(auth, streamId).Execute()
 		if err != nil {
 			return err
 		} else if !disableResponse {
@@ -0,0 +1,135 @@
+// Copyright (c) Sander Jochems
+// SPDX-License-Identifier: MIT
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"github.com/sander0542/terraform-provider-nginxproxymanager/internal/provider/models"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/sander0542/nginxproxymanager-go"
+)
+
+var _ datasource.DataSource = &UsageDataSource{}
+
+func NewUsageDataSource() datasource.DataSource {
+	return &UsageDataSource{}
+}
+
+type UsageDataSource struct {
+	client *nginxproxymanager.APIClient
+	auth   context.Context
+}
+
+func (d *UsageDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_usage"
+}
+
+func (d *UsageDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Hosts --- This data source can be used to find out which certificates and access lists are used by the proxy hosts, redirection hosts, dead hosts and streams.",
+		Attributes: map[string]schema.Attribute{
+			"unused_certificate_ids": schema.SetAttribute{
+				MarkdownDescription: "The Ids of the certificates that are not used by any host.",
+				Computed:            true,
+				ElementType:         types.Int64Type,
+			},
+			"unused_access_list_ids": schema.SetAttribute{
+				MarkdownDescription: "The Ids of the access lists that are not used by any proxy host.",
+				Computed:            true,
+				ElementType:         types.Int64Type,
+			},
+			"missing_references": schema.SetNestedAttribute{
+				MarkdownDescription: "The hosts that use a certificate or access list that does not exist.",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"host_type": schema.StringAttribute{
+							MarkdownDescription: "The type of the host, either `proxy_host`, `redirection_host`, `dead_host` or `stream`.",
+							Computed:            true,
+						},
+						"host_id": schema.Int64Attribute{
+							MarkdownDescription: "The Id of the host.",
+							Computed:            true,
+						},
+						"host_name": schema.StringAttribute{
+							MarkdownDescription: "The domain names of the host, or the incoming port of the stream.",
+							Computed:            true,
+						},
+						"object_type": schema.StringAttribute{
+							MarkdownDescription: "The type of the missing object, either `certificate` or `access_list`.",
+							Computed:            true,
+						},
+						"object_id": schema.Int64Attribute{
+							MarkdownDescription: "The Id of the missing object.",
+							Computed:            true,
+						},
+					},
+				},
+			},
+			"certificate_reference_counts": schema.MapAttribute{
+				MarkdownDescription: "The number of hosts using each certificate, keyed by the Id of the certificate.",
+				Computed:            true,
+				ElementType:         types.Int64Type,
+			},
+			"access_list_reference_counts": schema.MapAttribute{
+				MarkdownDescription: "The number of proxy hosts using each access list, keyed by the Id of the access list.",
+				Computed:            true,
+				ElementType:         types.Int64Type,
+			},
+		},
+	}
+}
+
+func (d *UsageDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if data := dataSourceConfigure(ctx, req, resp); data != nil {
+		d.client = data.Client
+		d.auth = data.Auth
+	}
+}
+
+func (d *UsageDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data *models.Usage
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	certificates, _, err := d.client.CertificatesAPI.GetCertificates(d.auth).Execute()
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read certificates, got error: %s", err))
+		return
+	}
+
+	accessLists, _, err := d.client.AccessListsAPI.GetAccessLists(d.auth).Execute()
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read access lists, got error: %s", err))
+		return
+	}
+
+	refs, err := loadReferences(d.client, d.auth)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read hosts, got error: %s", err))
+		return
+	}
+
+	certificateIds := make([]int64, 0, len(certificates))
+	for _, certificate := range certificates {
+		certificateIds = append(certificateIds, certificate.GetId())
+	}
+
+	accessListIds := make([]int64, 0, len(accessLists))
+	for _, accessList := range accessLists {
+		accessListIds = append(accessListIds, accessList.GetId())
+	}
+
+	data.Write(ctx, certificateIds, accessListIds, refs.Certificates, refs.AccessLists, &resp.Diagnostics)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,63 @@
+// Copyright (c) Sander Jochems
+// SPDX-License-Identifier: MIT
+
+package provider
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+)
+
+func TestAccUsageDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Unauthorized
+			{
+				Config:      testAccUsageDataSourceConfig + testUnauthorizedProvider,
+				ExpectError: regexp.MustCompile("Failed to authenticate with the Nginx Proxy Manager API"),
+			},
+			// Read testing
+			{
+				Config: testAccUsageDataSourceConfig,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						"data.nginxproxymanager_usage.test",
+						tfjsonpath.New("unused_certificate_ids"),
+						knownvalue.NotNull(),
+					),
+					statecheck.ExpectKnownValue(
+						"data.nginxproxymanager_usage.test",
+						tfjsonpath.New("unused_access_list_ids"),
+						knownvalue.NotNull(),
+					),
+					statecheck.ExpectKnownValue(
+						"data.nginxproxymanager_usage.test",
+						tfjsonpath.New("missing_references"),
+						knownvalue.NotNull(),
+					),
+					statecheck.ExpectKnownValue(
+						"data.nginxproxymanager_usage.test",
+						tfjsonpath.New("certificate_reference_counts"),
+						knownvalue.NotNull(),
+					),
+					statecheck.ExpectKnownValue(
+						"data.nginxproxymanager_usage.test",
+						tfjsonpath.New("access_list_reference_counts"),
+						knownvalue.NotNull(),
+					),
+				},
+			},
+		},
+	})
+}
+
+const testAccUsageDataSourceConfig = `
+data "nginxproxymanager_usage" "test" {}
+`
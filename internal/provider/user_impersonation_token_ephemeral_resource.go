@@ -0,0 +1,78 @@
+// Copyright (c) Sander Jochems
+// SPDX-License-Identifier: MIT
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"github.com/sander0542/nginxproxymanager-go"
+	"github.com/sander0542/terraform-provider-nginxproxymanager/internal/provider/models"
+
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral/schema"
+)
+
+var _ ephemeral.EphemeralResource = &UserImpersonationTokenEphemeralResource{}
+var _ ephemeral.EphemeralResourceWithConfigure = &UserImpersonationTokenEphemeralResource{}
+
+func NewUserImpersonationTokenEphemeralResource() ephemeral.EphemeralResource {
+	return &UserImpersonationTokenEphemeralResource{}
+}
+
+type UserImpersonationTokenEphemeralResource struct {
+	client *nginxproxymanager.APIClient
+	auth   context.Context
+}
+
+func (r *UserImpersonationTokenEphemeralResource) Metadata(_ context.Context, req ephemeral.MetadataRequest, resp *ephemeral.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_user_impersonation_token"
+}
+
+func (r *UserImpersonationTokenEphemeralResource) Schema(ctx context.Context, _ ephemeral.SchemaRequest, resp *ephemeral.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Users --- This ephemeral resource can be used to retrieve an ephemeral token for another user. The provider must be authenticated as an administrator.",
+		Attributes: map[string]schema.Attribute{
+			"user_id": schema.Int64Attribute{
+				MarkdownDescription: "The Id of the user to log in as.",
+				Required:            true,
+			},
+			"token": schema.StringAttribute{
+				Computed:            true,
+				Sensitive:           true,
+				MarkdownDescription: "The token for the impersonated user.",
+			},
+			"expires": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The date and time the token expires.",
+			},
+		},
+	}
+}
+
+func (r *UserImpersonationTokenEphemeralResource) Configure(ctx context.Context, req ephemeral.ConfigureRequest, resp *ephemeral.ConfigureResponse) {
+	if data := ephemeralResourceConfigure(ctx, req, resp); data != nil {
+		r.client = data.Client
+		r.auth = data.Auth
+	}
+}
+
+func (r *UserImpersonationTokenEphemeralResource) Open(ctx context.Context, req ephemeral.OpenRequest, resp *ephemeral.OpenResponse) {
+	var data *models.UserImpersonationToken
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	login, _, err := r.client.UsersAPI.LoginAsUser(r.auth, data.UserId.ValueInt64()).Execute()
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to log in as user, got error: %s", err))
+		return
+	}
+
+	data.Write(ctx, login, &resp.Diagnostics)
+
+	resp.Diagnostics.Append(resp.Result.Set(ctx, &data)...)
+}
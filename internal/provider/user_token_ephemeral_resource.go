@@ -5,8 +5,12 @@ package provider
 
 import (
 	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/sander0542/nginxproxymanager-go"
 	"github.com/sander0542/terraform-provider-nginxproxymanager/internal/provider/models"
+	"regexp"
 
 	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
 	"github.com/hashicorp/terraform-plugin-framework/ephemeral/schema"
@@ -31,13 +35,31 @@ func (r *UserTokenEphemeralResource) Metadata(_ context.Context, req ephemeral.M
 
 func (r *UserTokenEphemeralResource) Schema(ctx context.Context, _ ephemeral.SchemaRequest, resp *ephemeral.SchemaResponse) {
 	resp.Schema = schema.Schema{
-		MarkdownDescription: "Users --- This ephemeral resource can be used to retrieve an ephemeral token for the current user.",
+		MarkdownDescription: "Users --- This ephemeral resource can be used to retrieve a new ephemeral token for the current user.",
 		Attributes: map[string]schema.Attribute{
+			"expiry": schema.StringAttribute{
+				MarkdownDescription: "How long the token is valid, as a number followed by a unit (e.g. `15m`, `2h` or `1d`). Defaults to `1d`. Tokens cannot be renewed, so the expiry must cover the whole Terraform run that uses the token.",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(regexp.MustCompile(`^[0-9]+(y|Q|M|w|d|h|m|s|ms)$`), "must be a number followed by a unit, such as `15m`, `2h` or `1d`"),
+				},
+			},
+			"scope": schema.StringAttribute{
+				MarkdownDescription: "The scope of the token. Only administrators can request a scope other than their own. Can be either `user`, `job-board` or `worker`.",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.OneOf("user", "job-board", "worker"),
+				},
+			},
 			"token": schema.StringAttribute{
 				Computed:            true,
 				Sensitive:           true,
 				MarkdownDescription: "The token for the current user.",
 			},
+			"expires": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The date and time the token expires.",
+			},
 		},
 	}
 }
@@ -58,13 +80,22 @@ func (r *UserTokenEphemeralResource) Open(ctx context.Context, req ephemeral.Ope
 		return
 	}
 
-	token, ok := r.auth.Value(nginxproxymanager.ContextAccessToken).(string)
+	request := r.client.TokensAPI.RefreshToken(r.auth)
+	if !data.Expiry.IsNull() {
+		request = request.Expiry(data.Expiry.ValueString())
+	}
+	if !data.Scope.IsNull() {
+		request = request.Scope(data.Scope.ValueString())
+	}
 
-	if !ok {
-		resp.Diagnostics.AddError("Client Error", "Unable to read token")
+	token, _, err := request.Execute()
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to request token, got error: %s", err))
+		return
 	}
 
-	data.Token = types.StringValue(token)
+	data.Token = types.StringValue(token.GetToken())
+	data.Expires = types.StringValue(token.GetExpires())
 
 	resp.Diagnostics.Append(resp.Result.Set(ctx, &data)...)
 }